@@ -51,6 +51,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/rpc"
 	"github.com/Onther-Tech/plasma-evm/signer/core"
 	"github.com/Onther-Tech/plasma-evm/signer/fourbyte"
+	"github.com/Onther-Tech/plasma-evm/signer/plasma"
 	"github.com/Onther-Tech/plasma-evm/signer/rules"
 	"github.com/Onther-Tech/plasma-evm/signer/storage"
 	colorable "github.com/mattn/go-colorable"
@@ -120,6 +121,14 @@ var (
 		Name:  "rules",
 		Usage: "Path to the rule file to auto-authorize requests with",
 	}
+	rootchainFlag = cli.StringFlag{
+		Name:  "plasma.rootchain",
+		Usage: "Address of the RootChain contract whose call data is decoded for rules",
+	}
+	depositManagerFlag = cli.StringFlag{
+		Name:  "plasma.depositmanager",
+		Usage: "Address of the DepositManager contract whose call data is decoded for rules",
+	}
 	stdiouiFlag = cli.BoolFlag{
 		Name: "stdio-ui",
 		Usage: "Use STDIN/STDOUT as a channel for an external UI. " +
@@ -219,6 +228,8 @@ func init() {
 		customDBFlag,
 		auditLogFlag,
 		ruleFlag,
+		rootchainFlag,
+		depositManagerFlag,
 		stdiouiFlag,
 		testFlag,
 		advancedMode,
@@ -513,6 +524,16 @@ func signer(c *cli.Context) error {
 	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
 	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
 
+	// Decode the call data of the plasma contracts for the rules
+	decoder, err := plasma.NewDecoder(plasma.Config{
+		RootChain:      common.HexToAddress(c.GlobalString(rootchainFlag.Name)),
+		DepositManager: common.HexToAddress(c.GlobalString(depositManagerFlag.Name)),
+	})
+	if err != nil {
+		utils.Fatalf(err.Error())
+	}
+	apiImpl.SetCallDecoder(decoder)
+
 	// Establish the bidirectional communication, by creating a new UI backend and registering
 	// it with the UI.
 	ui.RegisterUIServer(core.NewUIServerAPI(apiImpl))
@@ -0,0 +1,63 @@
+// Ruleset for an unattended plasma operator.
+//
+// Run clef with the chain id of the root chain and the RootChain address:
+//
+//   clef --chainid <root chain id> --plasma.rootchain <RootChain> --rules plasma-operator.js
+//
+// The ruleset approves only the block submissions of the operator and the exit
+// challenges of the challenger to the RootChain contract within the gas caps,
+// and the clique headers of the operator. Everything else goes to manual
+// processing.
+
+var rootchain  = "0x0000000000000000000000000000000000000000"
+var operator   = "0x0000000000000000000000000000000000000000"
+var challenger = "0x0000000000000000000000000000000000000000"
+
+var maxGas      = 7000000               // gas cap of a single transaction
+var maxGasPrice = "200000000000"        // 200 gwei, in wei
+var maxValue    = "1000000000000000000" // 1 ether, cost of a block submission
+
+var allowed = {
+	"submitNRE":     operator,
+	"submitORB":     operator,
+	"challengeExit": challenger,
+}
+
+function asBig(str) {
+	if (str.slice(0, 2) == "0x") {
+		return new BigNumber(str.slice(2), 16)
+	}
+	return new BigNumber(str)
+}
+
+function ApproveTx(r) {
+	var tx = r.transaction
+	var call = r.decoded
+
+	// the call must be decoded against the RootChain ABI
+	if (!tx.to || tx.to.toLowerCase() != rootchain || !call || call.contract != "RootChain") {
+		return
+	}
+	if (allowed[call.method] != tx.from.toLowerCase()) {
+		return
+	}
+	if (asBig(tx.gas).gt(maxGas) || asBig(tx.gasPrice).gt(asBig(maxGasPrice))) {
+		console.log("Gas cap exceeded", call.method, tx.gas, tx.gasPrice)
+		return "Reject"
+	}
+	if (asBig(tx.value).gt(asBig(maxValue)) || (call.method == "challengeExit" && !asBig(tx.value).isZero())) {
+		console.log("Value cap exceeded", call.method, tx.value)
+		return "Reject"
+	}
+	return "Approve"
+}
+
+function ApproveSignData(r) {
+	if (r.address.toLowerCase() == operator && r.content_type == "application/x-clique-header") {
+		return "Approve"
+	}
+}
+
+function ApproveListing() {
+	return "Approve"
+}
@@ -0,0 +1,537 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/cmd/utils"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/depositmanager"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/powerton"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchainregistry"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/seigmanager"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/ton"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/wton"
+	"github.com/Onther-Tech/plasma-evm/core"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/ethdb"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/params"
+	"github.com/naoina/toml"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	bootstrapCommand = cli.Command{
+		Action:    utils.MigrateFlags(bootstrap),
+		Name:      "bootstrap",
+		Usage:     "Deploy and set up RootChain and staking contracts from a manifest",
+		ArgsUsage: "<manifest.toml>",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.RootChainUrlFlag,
+			utils.UnlockedAccountFlag,
+			utils.PasswordFileFlag,
+			utils.RootChainSenderFlag,
+			utils.DeveloperKeyFlag,
+			utils.RootChainGasPriceFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The bootstrap command runs below steps in order as described in the manifest.
+
+ 1. deployManagers    (geth manage-staking deployManagers)
+ 2. deploy            (geth deploy)
+ 3. deployPowerTON    (geth manage-staking deployPowerTON)
+ 4. startPowerTON     (geth manage-staking startPowerTON)
+ 5. register          (geth manage-staking register)
+ 6. setCommissionRate (geth manage-staking setCommissionRate)
+ 7. exportManagers    (geth manage-staking getManagers)
+
+Deployed contract addresses are recorded in the datadir as setManagers does.
+Steps already done are skipped, so bootstrap resumes from the first incomplete
+step if it is run again with the same datadir. Each manager contract is recorded
+as soon as it is deployed, so deployManagers resumes from the first contract
+not deployed.
+
+Manifest example:
+
+	[RootChain]
+	GenesisPath = "genesis.json"
+	ChainID = 16
+	WithPETH = true
+	NRELength = 2
+
+	[Stamina]
+	OperatorAmount = 0.1
+	MinDeposit = 0.5
+	RecoverEpochLength = 120960
+	WithdrawalDelay = 362880
+
+	[Staking]
+	WithdrawalDelay = 10
+	SeigPerBlock = "3.92"
+	PowerTONRoundDuration = "1h"
+	StartPowerTON = true
+	CommissionRate = "0.1"
+	ManagersPath = "managers.json"
+
+Omit PowerTONRoundDuration or CommissionRate to skip the steps. Set Staking.TON
+or Staking.WTON to use already deployed token contracts.
+
+After bootstrap, initialize the node with 'geth init <GenesisPath>'.
+`,
+	}
+)
+
+type bootstrapManifest struct {
+	RootChain struct {
+		GenesisPath string
+		ChainID     uint64
+		WithPETH    bool
+		NRELength   uint64
+	}
+
+	// Zero values are replaced with the default stamina parameters.
+	Stamina struct {
+		OperatorAmount     float64 // in ETH
+		MinDeposit         float64 // in ETH
+		RecoverEpochLength uint64
+		WithdrawalDelay    uint64
+	}
+
+	Staking struct {
+		TON  string `toml:",omitempty"`
+		WTON string `toml:",omitempty"`
+
+		WithdrawalDelay uint64
+		SeigPerBlock    string // in WTON
+
+		PowerTONRoundDuration string `toml:",omitempty"`
+		StartPowerTON         bool
+
+		CommissionRate string `toml:",omitempty"`
+
+		ManagersPath string `toml:",omitempty"`
+	}
+}
+
+func loadBootstrapManifest(file string) (*bootstrapManifest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	manifest := new(bootstrapManifest)
+	err = tomlSettings.NewDecoder(bufio.NewReader(f)).Decode(manifest)
+	// Add file name to errors that have a line number.
+	if _, ok := err.(*toml.LineError); ok {
+		err = errors.New(file + ", " + err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(manifest.RootChain.GenesisPath) == 0 {
+		return nil, errors.New("RootChain.GenesisPath is empty")
+	}
+	if manifest.RootChain.ChainID == 0 {
+		return nil, errors.New("RootChain.ChainID cannot be zero")
+	}
+	if manifest.RootChain.NRELength == 0 {
+		return nil, errors.New("RootChain.NRELength cannot be zero")
+	}
+	if manifest.Staking.WithdrawalDelay == 0 {
+		return nil, errors.New("Staking.WithdrawalDelay cannot be zero")
+	}
+	if len(manifest.Staking.SeigPerBlock) == 0 {
+		return nil, errors.New("Staking.SeigPerBlock is empty")
+	}
+	if manifest.Staking.StartPowerTON && len(manifest.Staking.PowerTONRoundDuration) == 0 {
+		return nil, errors.New("Staking.PowerTONRoundDuration is required to start PowerTON")
+	}
+
+	return manifest, nil
+}
+
+func (m *bootstrapManifest) staminaConfig() *params.StaminaConfig {
+	config := &params.StaminaConfig{
+		Initialized:        true,
+		OperatorAmount:     params.DefaultOperatorStamina,
+		MinDeposit:         params.DefaultMinDeposit,
+		RecoverEpochLength: params.DefaultRecoverEpochLength,
+		WithdrawalDelay:    params.DefaultWithdrawalDelay,
+	}
+
+	if m.Stamina.OperatorAmount != 0 {
+		config.OperatorAmount = params.ToEtherBigInt(m.Stamina.OperatorAmount)
+	}
+	if m.Stamina.MinDeposit != 0 {
+		config.MinDeposit = params.ToEtherBigInt(m.Stamina.MinDeposit)
+	}
+	if m.Stamina.RecoverEpochLength != 0 {
+		config.RecoverEpochLength = new(big.Int).SetUint64(m.Stamina.RecoverEpochLength)
+	}
+	if m.Stamina.WithdrawalDelay != 0 {
+		config.WithdrawalDelay = new(big.Int).SetUint64(m.Stamina.WithdrawalDelay)
+	}
+
+	return config
+}
+
+// bootstrapStep is a step of bootstrap. A step is skipped if done reports the
+// step is already done.
+type bootstrapStep struct {
+	name string
+	done func() (bool, error)
+	run  func() error
+}
+
+func bootstrap(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("Expected 1 parameter, not %d", len(ctx.Args()))
+	}
+
+	manifest, err := loadBootstrapManifest(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	stack, cfg := makeConfigNode(ctx)
+	opt, backend := initOpts(ctx, stack, &cfg.Pls)
+
+	if opt == nil {
+		utils.Fatalf("Transaction sender is not set. Use --rootchain.sender flag")
+	}
+
+	stakedb, err := stack.OpenDatabase("stakingdata", 0, 0, "")
+	defer stakedb.Close()
+	if err != nil {
+		utils.Fatalf("Failed to open database: %v", err)
+	}
+
+	staminaConfig := manifest.staminaConfig()
+	if new(big.Int).Mul(staminaConfig.RecoverEpochLength, big.NewInt(2)).Cmp(staminaConfig.WithdrawalDelay) >= 0 {
+		utils.Fatalf("Expected withdrawal delay to be more than %v recovery epoch length by two times, but is %v", staminaConfig.RecoverEpochLength, staminaConfig.WithdrawalDelay)
+	}
+
+	seigPerBlock := parseFloatString(manifest.Staking.SeigPerBlock, params.WTONDecimals)
+
+	var roundDuration *big.Int
+	if len(manifest.Staking.PowerTONRoundDuration) != 0 {
+		d, err := time.ParseDuration(manifest.Staking.PowerTONRoundDuration)
+		if err != nil {
+			utils.Fatalf("Failed to parse PowerTON round duration: %v", err)
+		}
+		roundDuration = big.NewInt(int64(d.Seconds()))
+	}
+
+	var commissionRate *big.Int
+	if len(manifest.Staking.CommissionRate) != 0 {
+		commissionRate = parseFloatString(manifest.Staking.CommissionRate, params.WTONDecimals)
+	}
+
+	callOpts := &bind.CallOpts{Pending: false}
+	managers := func() *ManagerConfig { return getManagerConfig(stakedb, ctx, false) }
+
+	// waitDeployed waits until the contract is deployed.
+	waitDeployed := func(addr common.Address, tx *types.Transaction, err error) (common.Address, error) {
+		if err != nil {
+			return common.Address{}, err
+		}
+		if err := plasma.WaitTx(backend, tx.Hash()); err != nil {
+			return common.Address{}, err
+		}
+		return addr, nil
+	}
+
+	steps := []bootstrapStep{
+		managerDeployStep("deployManagers/TON", stakedb, rawdb.ReadTON, rawdb.WriteTON, func() (common.Address, error) {
+			if len(manifest.Staking.TON) != 0 {
+				return common.HexToAddress(manifest.Staking.TON), nil
+			}
+			addr, tx, _, err := ton.DeployTON(opt, backend)
+			return waitDeployed(addr, tx, err)
+		}),
+		managerDeployStep("deployManagers/WTON", stakedb, rawdb.ReadWTON, rawdb.WriteWTON, func() (common.Address, error) {
+			if len(manifest.Staking.WTON) != 0 {
+				addr := common.HexToAddress(manifest.Staking.WTON)
+				WTON, err := wton.NewWTON(addr, backend)
+				if err != nil {
+					return common.Address{}, err
+				}
+				if seigManager, _ := WTON.SeigManager(callOpts); (seigManager != common.Address{}) {
+					return common.Address{}, errors.New("WTON already set SeigManager")
+				}
+				return addr, nil
+			}
+			addr, tx, _, err := wton.DeployWTON(opt, backend, rawdb.ReadTON(stakedb))
+			return waitDeployed(addr, tx, err)
+		}),
+		managerDeployStep("deployManagers/RootChainRegistry", stakedb, rawdb.ReadRegistry, rawdb.WriteRegistry, func() (common.Address, error) {
+			addr, tx, _, err := rootchainregistry.DeployRootChainRegistry(opt, backend)
+			return waitDeployed(addr, tx, err)
+		}),
+		managerDeployStep("deployManagers/DepositManager", stakedb, rawdb.ReadDepositManager, rawdb.WriteDepositManager, func() (common.Address, error) {
+			addr, tx, _, err := depositmanager.DeployDepositManager(opt, backend, rawdb.ReadWTON(stakedb), rawdb.ReadRegistry(stakedb),
+				new(big.Int).SetUint64(manifest.Staking.WithdrawalDelay))
+			return waitDeployed(addr, tx, err)
+		}),
+		managerDeployStep("deployManagers/SeigManager", stakedb, rawdb.ReadSeigManager, rawdb.WriteSeigManager, func() (common.Address, error) {
+			m := managers()
+			addr, tx, _, err := seigmanager.DeploySeigManager(opt, backend, m.TON, m.WTON, m.RootChainRegistry, m.DepositManager, seigPerBlock)
+			return waitDeployed(addr, tx, err)
+		}),
+		{
+			name: "deployManagers/setup",
+			// SetupManagers skips the settings already done
+			done: func() (bool, error) { return false, nil },
+			run: func() error {
+				m := managers()
+				return plasma.SetupManagers(opt, backend, m.TON, m.WTON, m.DepositManager, m.SeigManager)
+			},
+		},
+		{
+			name: "deploy",
+			done: func() (bool, error) {
+				if (rawdb.ReadRootChain(stakedb) == common.Address{}) {
+					return false, nil
+				}
+				_, err := os.Stat(manifest.RootChain.GenesisPath)
+				return err == nil, nil
+			},
+			run: func() error {
+				// RootChain is deployed but genesis file is missing
+				if (rawdb.ReadRootChain(stakedb) != common.Address{}) {
+					return exportBootstrapGenesis(stakedb, manifest.RootChain.GenesisPath)
+				}
+
+				rootchainAddr, genesis, err := plasma.DeployPlasmaContracts(opt, backend, staminaConfig, managers().TON,
+					manifest.RootChain.WithPETH, false, new(big.Int).SetUint64(manifest.RootChain.NRELength))
+				if err != nil {
+					return err
+				}
+				genesis.Config.ChainID = new(big.Int).SetUint64(manifest.RootChain.ChainID)
+
+				data, err := json.Marshal(genesis)
+				if err != nil {
+					return err
+				}
+
+				rawdb.WriteGenesis(stakedb, data)
+				rawdb.WriteRootChain(stakedb, rootchainAddr)
+
+				log.Info("RootChain deployed", "RootChain", rootchainAddr)
+
+				return utils.ExportGenesis(genesis, manifest.RootChain.GenesisPath)
+			},
+		},
+	}
+
+	if roundDuration != nil {
+		steps = append(steps, bootstrapStep{
+			name: "deployPowerTON",
+			done: func() (bool, error) {
+				return managers().PowerTON != common.Address{}, nil
+			},
+			run: func() error {
+				m := managers()
+				powertonAddr, err := plasma.DeployPowerTON(opt, backend, m.WTON, m.SeigManager, roundDuration)
+				if err != nil {
+					return err
+				}
+
+				log.Info("PowerTON deployed", "PowerTON", powertonAddr, "WTON", m.WTON, "SeigManager", m.SeigManager)
+
+				rawdb.WritePowerTON(stakedb, powertonAddr)
+				return nil
+			},
+		})
+	}
+
+	if manifest.Staking.StartPowerTON {
+		steps = append(steps, bootstrapStep{
+			name: "startPowerTON",
+			done: func() (bool, error) {
+				pton, err := powerton.NewPowerTON(managers().PowerTON, backend)
+				if err != nil {
+					return false, err
+				}
+				round, err := pton.CurrentRound(callOpts)
+				if err != nil {
+					return false, err
+				}
+				r, err := pton.Rounds(callOpts, round)
+				if err != nil {
+					return false, err
+				}
+				return r.StartTime != 0, nil
+			},
+			run: func() error {
+				pton, err := powerton.NewPowerTON(managers().PowerTON, backend)
+				if err != nil {
+					return err
+				}
+				tx, err := pton.Start(opt)
+				if err != nil {
+					return err
+				}
+				if err := plasma.WaitTx(backend, tx.Hash()); err != nil {
+					return err
+				}
+
+				log.Info("PowerTON started", "PowerTON", managers().PowerTON)
+				return nil
+			},
+		})
+	}
+
+	steps = append(steps, bootstrapStep{
+		name: "register",
+		done: func() (bool, error) {
+			m := managers()
+			rootchainAddr := rawdb.ReadRootChain(stakedb)
+
+			rootchainCtr, err := rootchain.NewRootChain(rootchainAddr, backend)
+			if err != nil {
+				return false, err
+			}
+			registry, err := rootchainregistry.NewRootChainRegistry(m.RootChainRegistry, backend)
+			if err != nil {
+				return false, err
+			}
+
+			seigManagerAddr, err := rootchainCtr.SeigManager(callOpts)
+			if err != nil {
+				return false, err
+			}
+			registered, err := registry.Rootchains(callOpts, rootchainAddr)
+			if err != nil {
+				return false, err
+			}
+			return seigManagerAddr == m.SeigManager && registered, nil
+		},
+		run: func() error {
+			return registerToSeigManager(opt, backend, managers(), rawdb.ReadRootChain(stakedb))
+		},
+	})
+
+	if commissionRate != nil {
+		steps = append(steps, bootstrapStep{
+			name: "setCommissionRate",
+			done: func() (bool, error) {
+				seigManager, err := seigmanager.NewSeigManager(managers().SeigManager, backend)
+				if err != nil {
+					return false, err
+				}
+				rate, err := seigManager.CommissionRates(callOpts, rawdb.ReadRootChain(stakedb))
+				if err != nil {
+					return false, err
+				}
+				return rate.Cmp(commissionRate) == 0, nil
+			},
+			run: func() error {
+				return setCommissionRateOf(opt, backend, managers(), rawdb.ReadRootChain(stakedb), commissionRate)
+			},
+		})
+	}
+
+	if len(manifest.Staking.ManagersPath) != 0 {
+		steps = append(steps, bootstrapStep{
+			name: "exportManagers",
+			done: func() (bool, error) { return false, nil },
+			run: func() error {
+				data, err := json.MarshalIndent(managers(), "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(manifest.Staking.ManagersPath, data, os.ModePerm); err != nil {
+					return err
+				}
+
+				log.Info("Exported manager contracts", "path", manifest.Staking.ManagersPath)
+				return nil
+			},
+		})
+	}
+
+	if err := runBootstrapSteps(steps); err != nil {
+		utils.Fatalf("%v", err)
+	}
+
+	logManagers(managers())
+	log.Info("Bootstrap completed", "RootChain", rawdb.ReadRootChain(stakedb), "genesis", manifest.RootChain.GenesisPath)
+
+	return nil
+}
+
+// managerDeployStep returns the step deploying a staking manager contract. The
+// address is recorded as soon as the contract is deployed, so the contracts
+// already deployed are not deployed again if a later one fails.
+func managerDeployStep(name string, db ethdb.Database, read func(ethdb.Reader) common.Address,
+	write func(ethdb.KeyValueWriter, common.Address), deploy func() (common.Address, error)) bootstrapStep {
+	return bootstrapStep{
+		name: name,
+		done: func() (bool, error) {
+			return read(db) != common.Address{}, nil
+		},
+		run: func() error {
+			addr, err := deploy()
+			if err != nil {
+				return err
+			}
+
+			log.Info("Staking manager contract recorded", "name", name, "addr", addr)
+			write(db, addr)
+			return nil
+		},
+	}
+}
+
+// runBootstrapSteps runs the steps in order, skipping steps already done. It
+// stops at the first failed step.
+func runBootstrapSteps(steps []bootstrapStep) error {
+	for i, step := range steps {
+		done, err := step.done()
+		if err != nil {
+			return fmt.Errorf("failed to check bootstrap step %d (%s): %v", i+1, step.name, err)
+		}
+		if done {
+			log.Info("Bootstrap step is already done", "step", i+1, "name", step.name)
+			continue
+		}
+
+		log.Info("Run bootstrap step", "step", i+1, "name", step.name)
+		if err := step.run(); err != nil {
+			return fmt.Errorf("bootstrap step %d (%s) failed, run bootstrap again to resume: %v", i+1, step.name, err)
+		}
+	}
+
+	return nil
+}
+
+// exportBootstrapGenesis exports the genesis recorded by bootstrap.
+func exportBootstrapGenesis(db ethdb.Reader, path string) error {
+	data := rawdb.ReadGenesis(db)
+	if len(data) == 0 {
+		return errors.New("genesis is not recorded")
+	}
+
+	genesis := new(core.Genesis)
+	if err := json.Unmarshal(data, genesis); err != nil {
+		return err
+	}
+
+	return utils.ExportGenesis(genesis, path)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/ethdb"
+)
+
+var errTestDeploy = errors.New("deploy failed")
+
+// testManagerSteps returns the steps deploying the staking manager contracts
+// into db. The contract at fail fails to deploy, and deployed counts the
+// deployments of each contract.
+func testManagerSteps(db ethdb.Database, fail string, deployed map[string]int) []bootstrapStep {
+	contracts := []struct {
+		name  string
+		read  func(ethdb.Reader) common.Address
+		write func(ethdb.KeyValueWriter, common.Address)
+	}{
+		{"TON", rawdb.ReadTON, rawdb.WriteTON},
+		{"WTON", rawdb.ReadWTON, rawdb.WriteWTON},
+		{"RootChainRegistry", rawdb.ReadRegistry, rawdb.WriteRegistry},
+		{"DepositManager", rawdb.ReadDepositManager, rawdb.WriteDepositManager},
+		{"SeigManager", rawdb.ReadSeigManager, rawdb.WriteSeigManager},
+	}
+
+	var steps []bootstrapStep
+	for i, c := range contracts {
+		name, addr := c.name, common.BytesToAddress([]byte{byte(i + 1)})
+
+		steps = append(steps, managerDeployStep(name, db, c.read, c.write, func() (common.Address, error) {
+			if name == fail {
+				return common.Address{}, errTestDeploy
+			}
+			deployed[name]++
+			return addr, nil
+		}))
+	}
+	return steps
+}
+
+// Tests that bootstrap resumes from the contract failed to deploy without
+// deploying the contracts already deployed again.
+func TestBootstrapResume(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	deployed := make(map[string]int)
+
+	if err := runBootstrapSteps(testManagerSteps(db, "DepositManager", deployed)); err == nil {
+		t.Fatal("bootstrap succeeded, want failure")
+	}
+	if len(deployed) != 3 || deployed["DepositManager"] != 0 || deployed["SeigManager"] != 0 {
+		t.Fatalf("deployed contracts mismatch: have %v, want 3", deployed)
+	}
+	if (rawdb.ReadRegistry(db) == common.Address{}) || (rawdb.ReadDepositManager(db) != common.Address{}) {
+		t.Fatal("deployed contracts are not recorded")
+	}
+
+	if err := runBootstrapSteps(testManagerSteps(db, "", deployed)); err != nil {
+		t.Fatalf("failed to resume bootstrap: %v", err)
+	}
+	for name, n := range deployed {
+		if n != 1 {
+			t.Errorf("%s deployed %d times, want 1", name, n)
+		}
+	}
+	if len(deployed) != 5 {
+		t.Errorf("deployed contracts mismatch: have %v, want 5", deployed)
+	}
+}
+
+// Tests that the steps are run in order until one fails, and the steps done
+// are skipped.
+func TestRunBootstrapSteps(t *testing.T) {
+	var ran []string
+	step := func(name string, done bool, err error) bootstrapStep {
+		return bootstrapStep{
+			name: name,
+			done: func() (bool, error) { return done, nil },
+			run: func() error {
+				ran = append(ran, name)
+				return err
+			},
+		}
+	}
+
+	err := runBootstrapSteps([]bootstrapStep{
+		step("a", true, nil),
+		step("b", false, nil),
+		step("c", false, errTestDeploy),
+		step("d", false, nil),
+	})
+	if err == nil {
+		t.Fatal("bootstrap succeeded, want failure")
+	}
+	if len(ran) != 2 || ran[0] != "b" || ran[1] != "c" {
+		t.Errorf("steps run mismatch: have %v, want [b c]", ran)
+	}
+}
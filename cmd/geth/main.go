@@ -124,6 +124,7 @@ var (
 		utils.MinerExtraDataFlag,
 		utils.MinerLegacyExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
+		utils.MinerMaxEpochDurationFlag,
 		utils.MinerNoVerfiyFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
@@ -173,16 +174,26 @@ var (
 
 	plasmaFlags = []cli.Flag{
 		utils.OperatorMinEtherFlag,
+		utils.OperatorLeaseFlag,
+		utils.OperatorLeaseTTLFlag,
+		utils.OperatorStandbyFlag,
 		utils.OperatorAddressFlag,
 		utils.OperatorKeyFlag,
 		utils.OperatorPasswordFileFlag,
 		utils.DeveloperKeyFlag,
 		utils.RootChainUrlFlag,
+		utils.RootChainFallbacksFlag,
 		utils.RootChainContractFlag,
 		utils.RootChainGasPriceFlag,
 		utils.TxMinGasPriceFlag,
 		utils.TxMaxGasPriceFlag,
 		utils.TxResubmitFlag,
+		utils.TxPipelineFlag,
+		utils.TxGasPricerFlag,
+		utils.TxGasPricerEscalationFlag,
+		utils.TxGasPricerPercentileFlag,
+		utils.TxGasPricerBlocksFlag,
+		utils.TxGasPricerOverridesFlag,
 		utils.ChallengerAddressFlag,
 		utils.ChallengerPasswordFileFlag,
 	}
@@ -220,6 +231,8 @@ var (
 		utils.RootChainSeigManagerFlag,
 		utils.RootChainPowerTONFlag,
 		utils.RootChainSenderFlag,
+		utils.StakingCommitIntervalFlag,
+		utils.StakingPowerTONKeeperFlag,
 	}
 )
 
@@ -232,6 +245,8 @@ func init() {
 		// See chaincmd.go:
 		initCommand,
 		deployCommand,
+		// See bootstrapcmd.go:
+		bootstrapCommand,
 		importCommand,
 		exportCommand,
 		importPreimagesCommand,
@@ -241,6 +256,8 @@ func init() {
 		removedbCommand,
 		dumpCommand,
 		inspectCommand,
+		// See plasmacmd.go:
+		plasmaCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
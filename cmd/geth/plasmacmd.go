@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Onther-Tech/plasma-evm/cmd/utils"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	replayForkFlag = cli.Uint64Flag{
+		Name:  "fork",
+		Usage: "Fork number of the epoch to replay",
+	}
+	replayEpochFlag = cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "Epoch number to replay",
+	}
+
+	plasmaCommand = cli.Command{
+		Name:     "plasma",
+		Usage:    "Inspect plasma chain against the root chain",
+		Category: "BLOCKCHAIN COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Name:   "replay",
+				Usage:  "Replay an epoch from the root chain data",
+				Action: utils.MigrateFlags(replayEpoch),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.RootChainUrlFlag,
+					utils.CacheFlag,
+					utils.SyncModeFlag,
+					utils.GCModeFlag,
+					replayForkFlag,
+					replayEpochFlag,
+				},
+				Description: `
+				geth plasma replay --fork N --epoch M
+
+Read the epoch and its requests from the RootChain contract and re-execute the
+epoch on a copy of the local state. Request transactions are rebuilt as the
+operator does when the epoch is prepared. The resulting state, transactions and
+receipts roots are compared with the submitted ones, and differences of each
+transaction from the local chain are printed.
+
+The state at the block preceding the epoch must be available, so use an archive
+node (--gcmode archive) to replay old epochs. The local database is not changed.
+`,
+			},
+		},
+	}
+)
+
+func replayEpoch(ctx *cli.Context) error {
+	if !ctx.GlobalIsSet(replayEpochFlag.Name) {
+		utils.Fatalf("--%s is required", replayEpochFlag.Name)
+	}
+	forkNumber := ctx.GlobalUint64(replayForkFlag.Name)
+	epochNumber := ctx.GlobalUint64(replayEpochFlag.Name)
+
+	stack, cfg := makeConfigNode(ctx)
+	chain, chainDb := utils.MakeChain(ctx, stack)
+	defer chainDb.Close()
+
+	if cfg.Pls.RootChainURL == "" {
+		return errors.New("root chain provider is not set. use --rootchain.url flag")
+	}
+	backend, err := ethclient.Dial(cfg.Pls.RootChainURL)
+	if err != nil {
+		utils.Fatalf("Failed to connect root chain: %v", err)
+	}
+
+	rootchainAddr := common.BytesToAddress(chain.Genesis().Extra())
+	if (rootchainAddr == common.Address{}) {
+		utils.Fatalf("RootChain is not set in genesis")
+	}
+	contract, err := rootchain.NewRootChain(rootchainAddr, backend)
+	if err != nil {
+		utils.Fatalf("Failed to load RootChain contract: %v", err)
+	}
+
+	log.Info("Replaying epoch", "rootchain", rootchainAddr, "fork", forkNumber, "epoch", epochNumber)
+
+	r, err := plasma.ReplayEpoch(chain, contract, forkNumber, epochNumber)
+	if err != nil {
+		utils.Fatalf("Failed to replay epoch: %v", err)
+	}
+
+	log.Info("Epoch", "fork", r.ForkNumber, "epoch", r.EpochNumber, "isRequest", r.IsRequest, "isEmpty", r.IsEmpty,
+		"startBlockNumber", r.StartBlockNumber, "endBlockNumber", r.EndBlockNumber)
+	if r.IsEmpty {
+		return nil
+	}
+
+	for _, b := range r.Blocks {
+		log.Info("Block replayed", "number", b.Number, "stateRoot", b.StateRoot.Hex(), "txRoot", b.TxRoot.Hex(), "receiptRoot", b.ReceiptRoot.Hex())
+
+		if b.IsRequest {
+			if !b.Submitted() {
+				log.Warn("Request block is not submitted", "number", b.Number)
+			} else {
+				printRootDiff(b.Number, "submitted stateRoot", b.StateRoot, b.SubmittedStateRoot)
+				printRootDiff(b.Number, "submitted txRoot", b.TxRoot, b.SubmittedTxRoot)
+				printRootDiff(b.Number, "submitted receiptRoot", b.ReceiptRoot, b.SubmittedReceiptRoot)
+			}
+		}
+		if (b.LocalStateRoot == common.Hash{}) {
+			log.Warn("Local block is not found", "number", b.Number)
+		} else {
+			printRootDiff(b.Number, "local stateRoot", b.StateRoot, b.LocalStateRoot)
+			printRootDiff(b.Number, "local txRoot", b.TxRoot, b.LocalTxRoot)
+			printRootDiff(b.Number, "local receiptRoot", b.ReceiptRoot, b.LocalReceiptRoot)
+		}
+
+		for _, d := range b.TxDiffs {
+			log.Warn("Transaction differs", "number", b.Number, "index", d.Index, "reason", d.Reason,
+				"hash", d.Hash.Hex(), "status", d.Status, "gasUsed", d.GasUsed,
+				"localHash", d.LocalHash.Hex(), "localStatus", d.LocalStatus, "localGasUsed", d.LocalGasUsed)
+		}
+	}
+
+	if !r.IsRequest {
+		printRootDiff(r.EndBlockNumber, "submitted epoch stateRoot", r.StateRoot, r.SubmittedStateRoot)
+		printRootDiff(r.EndBlockNumber, "submitted epoch txRoot", r.TxRoot, r.SubmittedTxRoot)
+		printRootDiff(r.EndBlockNumber, "submitted epoch receiptRoot", r.ReceiptRoot, r.SubmittedReceiptRoot)
+	}
+
+	if !r.Matched() {
+		return fmt.Errorf("replayed epoch#%d of fork#%d does not match the submitted roots", epochNumber, forkNumber)
+	}
+	log.Info("Replayed epoch matches the submitted roots", "fork", forkNumber, "epoch", epochNumber)
+	return nil
+}
+
+func printRootDiff(number uint64, name string, replayed, expected common.Hash) {
+	if replayed != expected {
+		log.Warn("Root mismatch", "number", number, "root", name, "replayed", replayed.Hex(), "expected", expected.Hex())
+	}
+}
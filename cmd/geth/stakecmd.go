@@ -289,6 +289,73 @@ Mint TON to account
 
 NOTE:
 use --rootchain.ton, --rootchain.wton, --rootchain.depositmanager, --rootchain.seigmanager flags to use already deployed token contracts
+`,
+			},
+			{
+				Name:      "seigniorage",
+				Usage:     "Project uncommitted seigniorage of the root chain",
+				ArgsUsage: "<address?>",
+				Action:    utils.MigrateFlags(getSeigniorage),
+				Category:  "TON STAKING COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.RootChainUrlFlag,
+					utils.RootChainSeigManagerFlag,
+					utils.StakingHorizonFlag,
+				},
+				Description: `
+				geth staking seigniorage <address?>
+
+Project seigniorage of the root chain which is not committed yet, with the commission
+of the operator and the PowerTON share. If address is given, the seigniorage of the
+delegator is projected too.
+
+NOTE:
+use --staking.horizon flag to project seigniorage after the number of root chain blocks
+use --rootchain.seigmanager flag to use already deployed token contracts
+`,
+			},
+			{
+				Name:      "withdrawals",
+				Usage:     "Print pending withdrawal requests",
+				ArgsUsage: "<address>",
+				Action:    utils.MigrateFlags(getWithdrawals),
+				Category:  "TON STAKING COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.RootChainUrlFlag,
+					utils.RootChainDepositManagerFlag,
+				},
+				Description: `
+				geth staking withdrawals <address>
+
+Print pending withdrawal requests of the account with the root chain block number
+each request becomes processable at.
+
+NOTE:
+use --rootchain.depositmanager flag to use already deployed token contracts
+`,
+			},
+			{
+				Name:      "powertonRounds",
+				Usage:     "Print PowerTON round history",
+				ArgsUsage: "<first?> <last?>",
+				Action:    utils.MigrateFlags(getPowerTONRounds),
+				Category:  "TON STAKING COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.RootChainUrlFlag,
+					utils.RootChainPowerTONFlag,
+				},
+				Description: `
+				geth staking powertonRounds <first?> <last?>
+
+Print PowerTON rounds from first to last with the winner and reward of each round.
+If last is not given, print until the current round. If first is not given, print
+recent 10 rounds.
+
+NOTE:
+use --rootchain.powerton flag to use already deployed token contracts
 `,
 			},
 			{
@@ -449,9 +516,7 @@ use --rootchain.depositmanager flags to use already deployed token contracts
 				Description: `
 				geth staking processWithdrawal <numRequests?>
 
-Process unstaking requests
-
-CAVEAT: <amount> should be a float
+Process unstaking requests. If numRequests is not given, process all matured requests.
 
 NOTE:
 use --rootchain.depositmanager flags to use already deployed token contracts
@@ -785,6 +850,61 @@ func startPowerTON(ctx *cli.Context) error {
 	return nil
 }
 
+func getPowerTONRounds(ctx *cli.Context) error {
+	if len(ctx.Args()) > 2 {
+		utils.Fatalf("Expected 2, 1 or 0 parameters, not %d", len(ctx.Args()))
+	}
+
+	stack, cfg := makeConfigNode(ctx)
+	_, backend := initOpts(ctx, stack, &cfg.Pls)
+
+	stakedb, err := stack.OpenDatabase("stakingdata", 0, 0, "")
+	defer stakedb.Close()
+	if err != nil {
+		utils.Fatalf("Failed to open database: %v", err)
+	}
+	managers := getManagerConfig(stakedb, ctx, true)
+
+	var first, last *uint64
+
+	if len(ctx.Args()) > 0 {
+		n, err := strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+		first = &n
+	}
+	if len(ctx.Args()) > 1 {
+		n, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			return err
+		}
+		last = &n
+	}
+
+	if (managers.PowerTON == common.Address{}) {
+		return errors.New("manager contract addresses is empty. please write contracts before register using `geth staking setManagers`")
+	}
+
+	h, err := plasma.ReadPowerTONHistory(backend, managers.PowerTON, first, last)
+	if err != nil {
+		utils.Fatalf("Failed to read PowerTON rounds: %v", err)
+	}
+
+	if h.Paused {
+		log.Warn("PowerTON is paused")
+	}
+
+	log.Info("PowerTON", "address", h.PowerTON, "blockNumber", h.BlockNumber, "currentRound", h.CurrentRound, "roundDuration", time.Duration(h.RoundDuration)*time.Second)
+
+	for _, r := range h.Rounds {
+		log.Info("PowerTON round", "round", r.Round, "startTime", time.Unix(int64(r.StartTime), 0), "endTime", time.Unix(int64(r.EndTime), 0),
+			"finished", r.Finished, "winner", r.Winner, "reward", bigIntToString(r.Reward, params.WTONDecimals)+" WTON")
+	}
+
+	return nil
+}
+
 func getManagers(ctx *cli.Context) error {
 	configPath := ctx.Args().First()
 
@@ -992,15 +1112,24 @@ func registerRootChain(ctx *cli.Context) error {
 
 	logManagers(managers)
 
+	if err := registerToSeigManager(opt, backend, managers, rootchainAddr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerToSeigManager registers SeigManager to RootChain and RootChain to
+// RootChainRegistry. Registrations already done are skipped.
+func registerToSeigManager(opt *bind.TransactOpts, backend *ethclient.Client, managers *ManagerConfig, rootchainAddr common.Address) error {
 	// load contract instances
 	registry, err := rootchainregistry.NewRootChainRegistry(managers.RootChainRegistry, backend)
 	if err != nil {
-		utils.Fatalf("Failed to load RootChainRegistry contract: %v", err)
+		return fmt.Errorf("failed to load RootChainRegistry contract: %v", err)
 	}
 	rootchainCtr, err := rootchain.NewRootChain(rootchainAddr, backend)
 	if err != nil {
-		utils.Fatalf("Failed to load RootChain contract: %v", err)
-
+		return fmt.Errorf("failed to load RootChain contract: %v", err)
 	}
 
 	// send transactions
@@ -1100,36 +1229,46 @@ func setCommissionRate(ctx *cli.Context) error {
 
 	logManagers(managers)
 
+	if err := setCommissionRateOf(opt, backend, managers, rootchainAddr, rate); err != nil {
+		utils.Fatalf("Failed to set commission rate: %v", err)
+	}
+
+	return nil
+}
+
+// setCommissionRateOf sets commission rate of the root chain. The transaction
+// sender should be the operator of the root chain.
+func setCommissionRateOf(opt *bind.TransactOpts, backend *ethclient.Client, managers *ManagerConfig, rootchainAddr common.Address, rate *big.Int) error {
 	// load contract instances
 	rootchainCtr, err := rootchain.NewRootChain(rootchainAddr, backend)
 	if err != nil {
-		utils.Fatalf("Failed to load RootChain contract: %v", err)
+		return fmt.Errorf("failed to load RootChain contract: %v", err)
 	}
 	seigManager, err := seigmanager.NewSeigManager(managers.SeigManager, backend)
 	if err != nil {
-		utils.Fatalf("Failed to load SeigManager contract: %v", err)
+		return fmt.Errorf("failed to load SeigManager contract: %v", err)
 	}
 
 	operator, err := rootchainCtr.Operator(&bind.CallOpts{Pending: false})
 	if err != nil {
-		utils.Fatalf("Failed to read operator: %v", err)
+		return fmt.Errorf("failed to read operator: %v", err)
 	}
 
 	if operator != opt.From {
-		utils.Fatalf("Transaction sender is not the operator: %s", opt.From)
+		return fmt.Errorf("transaction sender is not the operator: %s", opt.From.String())
 	}
 
 	minRate, err := seigManager.MINVALIDCOMMISSION(&bind.CallOpts{Pending: false})
 	if err != nil {
-		utils.Fatalf("Failed to MIN_VALID_COMMISSION_RATE: %v", err)
+		return fmt.Errorf("failed to read MIN_VALID_COMMISSION_RATE: %v", err)
 	}
 	maxRate, err := seigManager.MAXVALIDCOMMISSION(&bind.CallOpts{Pending: false})
 	if err != nil {
-		utils.Fatalf("Failed to MAX_VALID_COMMISSION_RATE: %v", err)
+		return fmt.Errorf("failed to read MAX_VALID_COMMISSION_RATE: %v", err)
 	}
 
 	if rate.Cmp(big.NewInt(0)) != 0 && (minRate.Cmp(rate) > 0 || maxRate.Cmp(rate) < 0) {
-		utils.Fatalf("Commission rate should be 0 or between %.2f and %.2f", params.ToRayFloat64(minRate), params.ToRayFloat64(maxRate))
+		return fmt.Errorf("commission rate should be 0 or between %.2f and %.2f", params.ToRayFloat64(minRate), params.ToRayFloat64(maxRate))
 	}
 
 	// send transaction
@@ -1138,14 +1277,10 @@ func setCommissionRate(ctx *cli.Context) error {
 
 	tx, err := seigManager.SetCommissionRate(opt, rootchainAddr, rate)
 	if err != nil {
-		utils.Fatalf("Failed to send transaction: %v", err)
+		return fmt.Errorf("failed to send transaction: %v", err)
 	}
 
-	if err = plasma.WaitTx(backend, tx.Hash()); err != nil {
-		utils.Fatalf("Failed to send transaction: %v", err)
-	}
-
-	return nil
+	return plasma.WaitTx(backend, tx.Hash())
 }
 
 // TODO: pending withdrawal amount
@@ -1303,6 +1438,111 @@ func getBalances(ctx *cli.Context) error {
 	return nil
 }
 
+func getSeigniorage(ctx *cli.Context) error {
+	if len(ctx.Args()) > 1 {
+		utils.Fatalf("Expected 1 or 0 parameters, not %d", len(ctx.Args()))
+	}
+
+	stack, cfg := makeConfigNode(ctx)
+	_, backend := initOpts(ctx, stack, &cfg.Pls)
+
+	stakedb, err := stack.OpenDatabase("stakingdata", 0, 0, "")
+	defer stakedb.Close()
+	if err != nil {
+		utils.Fatalf("Failed to open database: %v", err)
+	}
+	managers := getManagerConfig(stakedb, ctx, true)
+	rootchainAddr := getRootChainAddr(cfg.Node.DataDir)
+
+	var account *common.Address
+	if len(ctx.Args()) == 1 {
+		addr := common.HexToAddress(ctx.Args().Get(0))
+		account = &addr
+	}
+
+	if (managers.SeigManager == common.Address{}) {
+		return errors.New("manager contract addresses is empty. please write contracts before register using `geth staking setManagers`")
+	}
+
+	logManagers(managers)
+
+	horizon := ctx.GlobalUint64(utils.StakingHorizonFlag.Name)
+
+	p, err := plasma.ProjectSeigniorage(backend, managers.SeigManager, rootchainAddr, account, horizon)
+	if err != nil {
+		utils.Fatalf("Failed to project seigniorage: %v", err)
+	}
+
+	if p.Paused {
+		log.Warn("SeigManager is paused")
+	}
+
+	log.Info("Seigniorage projection", "rootchain", rootchainAddr, "blockNumber", p.BlockNumber, "horizon", p.Horizon, "lastSeigBlock", p.LastSeigBlock, "lastCommitBlock", p.LastCommitBlock)
+	log.Info("Seigniorage Per Block", "amount", bigIntToString(p.SeigPerBlock, params.WTONDecimals)+" WTON")
+
+	log.Info("Total Stake", "amount", bigIntToString(p.TotalStake, params.WTONDecimals)+" WTON")
+	log.Info("Total Stake of Root Chain", "amount", bigIntToString(p.RootChainStake, params.WTONDecimals)+" WTON", "rootchain", rootchainAddr)
+	log.Info("Comitted Stake of Root Chain", "amount", bigIntToString(p.CommittedStake, params.WTONDecimals)+" WTON", "rootchain", rootchainAddr)
+
+	log.Info("Total Seigniorage", "amount", bigIntToString(p.MaxSeig, params.WTONDecimals)+" WTON")
+	log.Info("Staked Seigniorage", "amount", bigIntToString(p.StakedSeig, params.WTONDecimals)+" WTON")
+	log.Info("Unstaked Seigniorage", "amount", bigIntToString(p.UnstakedSeig, params.WTONDecimals)+" WTON")
+	log.Info("PowerTON Seigniorage", "amount", bigIntToString(p.PowerTONSeig, params.WTONDecimals)+" WTON", "powerton", p.PowerTON,
+		"ratio", fmt.Sprintf("%s/%s", p.PowerTONNumerator, p.PowerTONDenominator))
+
+	log.Info("Uncomitted Seigniorage of Root Chain", "amount", bigIntToString(p.UncommittedSeig, params.WTONDecimals)+" WTON", "rootchain", rootchainAddr)
+	log.Info("Commission", "amount", bigIntToString(p.CommissionSeig, params.WTONDecimals)+" WTON", "rate", params.ToRayFloat64(p.CommissionRate), "operator", p.Operator)
+	log.Info("Delegators Seigniorage", "amount", bigIntToString(p.DelegatorsSeig, params.WTONDecimals)+" WTON")
+
+	if account != nil {
+		log.Info("Comitted Stake", "amount", bigIntToString(p.AccountStake, params.WTONDecimals)+" WTON", "rootchain", rootchainAddr, "depositor", *account)
+		log.Info("Uncomitted Seigniorage", "amount", bigIntToString(p.AccountSeig, params.WTONDecimals)+" WTON", "rootchain", rootchainAddr, "depositor", *account)
+	}
+
+	return nil
+}
+
+func getWithdrawals(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("Expected 1 parameter, not %d", len(ctx.Args()))
+	}
+
+	stack, cfg := makeConfigNode(ctx)
+	_, backend := initOpts(ctx, stack, &cfg.Pls)
+
+	stakedb, err := stack.OpenDatabase("stakingdata", 0, 0, "")
+	defer stakedb.Close()
+	if err != nil {
+		utils.Fatalf("Failed to open database: %v", err)
+	}
+	managers := getManagerConfig(stakedb, ctx, true)
+	rootchainAddr := getRootChainAddr(cfg.Node.DataDir)
+
+	account := common.HexToAddress(ctx.Args().Get(0))
+
+	if (managers.DepositManager == common.Address{}) {
+		return errors.New("manager contract addresses is empty. please write contracts before register using `geth staking setManagers`")
+	}
+
+	logManagers(managers)
+
+	q, err := plasma.ReadWithdrawalQueue(backend, managers.DepositManager, rootchainAddr, account)
+	if err != nil {
+		utils.Fatalf("Failed to read withdrawal requests: %v", err)
+	}
+
+	log.Info("Withdrawal requests", "rootchain", rootchainAddr, "depositor", account, "blockNumber", q.BlockNumber, "withdrawalDelay", q.WithdrawalDelay, "numRequests", q.NumRequests, "nextIndex", q.NextIndex)
+
+	for _, r := range q.Requests {
+		log.Info("Withdrawal request", "index", r.Index, "amount", bigIntToString(r.Amount, params.WTONDecimals)+" WTON", "withdrawableBlockNumber", r.WithdrawableBlockNumber, "processable", r.Processable)
+	}
+
+	log.Info("Pending Withdrawal", "amount", bigIntToString(q.PendingAmount, params.WTONDecimals)+" WTON", "numRequests", len(q.Requests))
+	log.Info("Processable Withdrawal", "amount", bigIntToString(q.ProcessableAmount, params.WTONDecimals)+" WTON", "numRequests", q.NumProcessable)
+
+	return nil
+}
+
 func mintTON(ctx *cli.Context) error {
 	if len(ctx.Args()) != 2 {
 		utils.Fatalf("Expected 2 parameters, not %d", len(ctx.Args()))
@@ -1777,28 +2017,30 @@ func processWithdrawal(ctx *cli.Context) error {
 
 	logManagers(managers)
 
-	var (
-		numPendingRequests *big.Int
-
-		depositManager *depositmanager.DepositManager
-	)
+	var depositManager *depositmanager.DepositManager
 
 	// load contract instances
 	if depositManager, err = depositmanager.NewDepositManager(managers.DepositManager, backend); err != nil {
 		utils.Fatalf("Failed to load DepositManager contract: %v", err)
 	}
 
-	if numPendingRequests, err = depositManager.NumPendingRequests(&bind.CallOpts{Pending: false}, rootchainAddr, opt.From); err != nil {
-		utils.Fatalf("Failed to read num pending requests: %v", err)
+	q, err := plasma.ReadWithdrawalQueue(backend, managers.DepositManager, rootchainAddr, opt.From)
+	if err != nil {
+		utils.Fatalf("Failed to read withdrawal requests: %v", err)
 	}
 
-	// check num pending requests
-	if n == 0 || int(numPendingRequests.Int64()) > n {
-		log.Warn("Set num requests", "n", numPendingRequests)
-		n = int(numPendingRequests.Int64())
+	// process matured requests only if no parameter given
+	if n == 0 {
+		n = int(q.NumProcessable)
+	} else if n > int(q.NumProcessable) {
+		log.Warn("Not enough matured requests", "requested", n, "processable", q.NumProcessable)
+		n = int(q.NumProcessable)
 	}
 
 	if n == 0 {
+		if len(q.Requests) > 0 {
+			utils.Fatalf("No matured request to process. Next request is processable at block #%d", q.Requests[0].WithdrawableBlockNumber)
+		}
 		utils.Fatalf("No request to process")
 	}
 
@@ -1810,8 +2052,9 @@ func processWithdrawal(ctx *cli.Context) error {
 		return err
 	}
 
-	// TODO: log request index and amount
-	log.Info("Withdraw request processed", "rootchain", rootchainAddr, "tx", tx.Hash())
+	for _, r := range q.Requests[:n] {
+		log.Info("Withdraw request processed", "rootchain", rootchainAddr, "index", r.Index, "amount", bigIntToString(r.Amount, params.WTONDecimals)+" WTON", "tx", tx.Hash())
+	}
 
 	return nil
 }
@@ -205,6 +205,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.MinerEtherbaseFlag,
 			utils.MinerExtraDataFlag,
 			utils.MinerRecommitIntervalFlag,
+			utils.MinerMaxEpochDurationFlag,
 			utils.MinerNoVerfiyFlag,
 		},
 	},
@@ -265,7 +266,11 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.OperatorKeyFlag,
 			utils.OperatorPasswordFileFlag,
 			utils.OperatorMinEtherFlag,
+			utils.OperatorLeaseFlag,
+			utils.OperatorLeaseTTLFlag,
+			utils.OperatorStandbyFlag,
 			utils.MinerRecommitIntervalFlag,
+			utils.MinerMaxEpochDurationFlag,
 		},
 	},
 	{
@@ -275,6 +280,12 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.TxMinGasPriceFlag,
 			utils.TxMaxGasPriceFlag,
 			utils.TxResubmitFlag,
+			utils.TxPipelineFlag,
+			utils.TxGasPricerFlag,
+			utils.TxGasPricerEscalationFlag,
+			utils.TxGasPricerPercentileFlag,
+			utils.TxGasPricerBlocksFlag,
+			utils.TxGasPricerOverridesFlag,
 		},
 	},
 	{
@@ -297,6 +308,7 @@ var AppHelpFlagGroups = []flagGroup{
 		Name: "PLASMA EVM - ROOTCHAIN CONTRACT",
 		Flags: []cli.Flag{
 			utils.RootChainUrlFlag,
+			utils.RootChainFallbacksFlag,
 			utils.RootChainContractFlag,
 		},
 	},
@@ -313,6 +325,8 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.RootChainDepositManagerFlag,
 			utils.RootChainSeigManagerFlag,
 			utils.RootChainPowerTONFlag,
+			utils.StakingCommitIntervalFlag,
+			utils.StakingPowerTONKeeperFlag,
 		},
 	},
 	{
@@ -45,7 +45,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/core"
 	"github.com/Onther-Tech/plasma-evm/core/vm"
 	"github.com/Onther-Tech/plasma-evm/crypto"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
 	"github.com/Onther-Tech/plasma-evm/ethdb"
 	"github.com/Onther-Tech/plasma-evm/ethstats"
 	"github.com/Onther-Tech/plasma-evm/graphql"
@@ -65,6 +65,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/pls/downloader"
 	"github.com/Onther-Tech/plasma-evm/pls/gasprice"
 	"github.com/Onther-Tech/plasma-evm/rpc"
+	"github.com/Onther-Tech/plasma-evm/tx"
 
 	whisper "github.com/Onther-Tech/plasma-evm/whisper/whisperv6"
 	pcsclite "github.com/gballet/go-libpcsclite"
@@ -468,6 +469,11 @@ var (
 		Usage: "Time interval to recreate the block being mined",
 		Value: pls.DefaultConfig.Miner.Recommit,
 	}
+	MinerMaxEpochDurationFlag = cli.DurationFlag{
+		Name:  "miner.maxepochduration",
+		Usage: "Maximum duration of a non-request epoch before empty blocks are sealed to complete it (0 = disabled)",
+		Value: pls.DefaultConfig.Miner.MaxEpochDuration,
+	}
 	MinerNoVerfiyFlag = cli.BoolFlag{
 		Name:  "miner.noverify",
 		Usage: "Disable remote sealing verification",
@@ -769,6 +775,19 @@ var (
 		Usage: "Plasma operator minimum balance (default = 0.5 ether)",
 		Value: "0.5",
 	}
+	OperatorLeaseFlag = cli.StringFlag{
+		Name:  "operator.lease",
+		Usage: "Lease file shared by the primary and the standby operator (enables hot standby)",
+	}
+	OperatorLeaseTTLFlag = cli.DurationFlag{
+		Name:  "operator.leasettl",
+		Usage: "Time after which the standby takes over if the primary does not renew the lease",
+		Value: pls.DefaultConfig.OperatorLeaseTTL,
+	}
+	OperatorStandbyFlag = cli.StringFlag{
+		Name:  "operator.standby",
+		Usage: "RPC endpoint of the primary operator to replicate, run as a standby",
+	}
 
 	// Challenger flags
 	ChallengerAddressFlag = cli.StringFlag{
@@ -786,6 +805,10 @@ var (
 		Name:  "rootchain.url",
 		Usage: "JSONRPC endpoint of rootchain provider. If URL is empty, ignore the provider.",
 	}
+	RootChainFallbacksFlag = cli.StringFlag{
+		Name:  "rootchain.fallbacks",
+		Usage: "Comma separated JSONRPC endpoints of rootchain providers to fail over to, in the order of preference",
+	}
 	RootChainGasPriceFlag = BigFlag{
 		Name:  "rootchain.gasPrice",
 		Usage: "Transaction gas price to root chain in GWei",
@@ -826,6 +849,20 @@ var (
 		Usage: "Address of PowerTON contract",
 	}
 
+	// Staking flags
+	StakingHorizonFlag = cli.Uint64Flag{
+		Name:  "staking.horizon",
+		Usage: "Number of root chain blocks from now to project seigniorage (default = 0, commit now)",
+	}
+	StakingCommitIntervalFlag = cli.Uint64Flag{
+		Name:  "staking.commitinterval",
+		Usage: "Maximum number of root chain blocks between seigniorage commits of operator (default = 0, commit only on submissions)",
+	}
+	StakingPowerTONKeeperFlag = cli.BoolFlag{
+		Name:  "staking.powertonkeeper",
+		Usage: "Enable operator to end PowerTON rounds once the round duration has elapsed",
+	}
+
 	// Transaction Flags
 	TxGasPriceFlag = BigFlag{
 		Name:  "tx.gasprice",
@@ -842,11 +879,40 @@ var (
 		Usage: "Maximum gas price for submitting a block (default = 100 Gwei)",
 		Value: pls.DefaultConfig.TxConfig.MaxGasPrice,
 	}
+	TxGasPricerFlag = cli.StringFlag{
+		Name:  "tx.gaspricer",
+		Usage: "Gas pricing strategy of root chain transactions (fixed, suggest, percentile or deadline)",
+		Value: pls.DefaultConfig.TxConfig.GasPricer.Strategy,
+	}
+	TxGasPricerEscalationFlag = cli.Uint64Flag{
+		Name:  "tx.gaspricer.escalation",
+		Usage: "Percentage added to the previous gas price of a stuck transaction",
+		Value: pls.DefaultConfig.TxConfig.GasPricer.Escalation,
+	}
+	TxGasPricerPercentileFlag = cli.IntFlag{
+		Name:  "tx.gaspricer.percentile",
+		Usage: "Percentile of gas prices in recent root chain blocks (percentile strategy)",
+		Value: pls.DefaultConfig.TxConfig.GasPricer.Percentile,
+	}
+	TxGasPricerBlocksFlag = cli.Uint64Flag{
+		Name:  "tx.gaspricer.blocks",
+		Usage: "Number of root chain blocks to sample (percentile strategy) or to mine a transaction in (deadline strategy)",
+		Value: pls.DefaultConfig.TxConfig.GasPricer.Blocks,
+	}
+	TxGasPricerOverridesFlag = cli.StringFlag{
+		Name:  "tx.gaspricer.overrides",
+		Usage: "Comma separated gas pricing strategies of transaction kinds (e.g. submitORB=deadline:50,challengeExit=suggest:100)",
+	}
 	TxResubmitFlag = cli.DurationFlag{
 		Name:  "tx.interval",
 		Usage: "Pending interval time after submitting a block (default = 10s). If block submit transaction is not mined in 2 intervals, gas price will be adjusted. See https://golang.org/pkg/time/#ParseDuration",
 		Value: pls.DefaultConfig.TxConfig.Interval,
 	}
+	TxPipelineFlag = cli.IntFlag{
+		Name:  "tx.pipeline",
+		Usage: "Maximum number of ORB submissions sent ahead of the first unmined transaction of an account (0 = send one by one)",
+		Value: pls.DefaultConfig.TxConfig.Pipeline,
+	}
 
 	// Stamina Flags
 	StaminaOperatorAmountFlag = cli.Float64Flag{
@@ -1241,6 +1307,38 @@ func readPassword(ctx *cli.Context, path string) string {
 	return lines[0]
 }
 
+// findPlasmaAccount returns the operator or challenger account. The account in
+// the local keystore is unlocked with the password file, and the account held
+// by the external signer is used as is since the signer approves every request.
+func findPlasmaAccount(ctx *cli.Context, stack *node.Node, ks *keystore.KeyStore, addr common.Address, passwordFlag string, role string) accounts.Account {
+	if ks == nil || !ks.HasAddress(addr) {
+		// the external signer lists its accounts only on request
+		for _, wallet := range stack.AccountManager().Wallets() {
+			for _, account := range wallet.Accounts() {
+				if account.Address == addr {
+					log.Info("Using external "+role+" account", "address", addr, "url", wallet.URL())
+					return account
+				}
+			}
+		}
+		Fatalf("Failed to find %s account: %v", role, accounts.ErrUnknownAccount)
+	}
+
+	account, err := ks.Find(accounts.Account{Address: addr})
+	if err != nil {
+		Fatalf("Failed to find %s account: %v", role, err)
+	}
+
+	pwd := readPassword(ctx, ctx.GlobalString(passwordFlag))
+
+	if err = ks.Unlock(account, pwd); err != nil {
+		Fatalf("Failed to unlock %s account: %v", role, err)
+	}
+	log.Info("Unlocked "+role+" account", "address", addr)
+
+	return account
+}
+
 func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	setNodeKey(ctx, cfg)
 	setNAT(ctx, cfg)
@@ -1481,6 +1579,9 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.GlobalIsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.GlobalIsSet(MinerMaxEpochDurationFlag.Name) {
+		cfg.MaxEpochDuration = ctx.Duration(MinerMaxEpochDurationFlag.Name)
+	}
 	if ctx.GlobalIsSet(MinerNoVerfiyFlag.Name) {
 		cfg.Noverify = ctx.Bool(MinerNoVerfiyFlag.Name)
 	}
@@ -1571,6 +1672,7 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 	CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
 	CheckExclusive(ctx, DeveloperFlag, RootChainContractFlag)
 	CheckExclusive(ctx, OperatorAddressFlag, OperatorKeyFlag)
+	CheckExclusive(ctx, ExternalSignerFlag, OperatorKeyFlag) // Can't import operator key into external signer
 
 	var ks *keystore.KeyStore
 	if keystores := stack.AccountManager().Backends(keystore.KeyStoreType); len(keystores) > 0 {
@@ -1586,7 +1688,7 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 
 	var (
 		operatorAddr     common.Address
-		rootchainBackend *ethclient.Client
+		rootchainBackend *failover.Client
 		err              error
 	)
 
@@ -1648,6 +1750,26 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 		cfg.OperatorMinEther = big.NewInt(int64(v * params.Ether))
 	}
 
+	if ctx.GlobalIsSet(OperatorLeaseFlag.Name) {
+		cfg.OperatorLeaseFile = ctx.GlobalString(OperatorLeaseFlag.Name)
+	}
+	if ctx.GlobalIsSet(OperatorLeaseTTLFlag.Name) {
+		cfg.OperatorLeaseTTL = ctx.GlobalDuration(OperatorLeaseTTLFlag.Name)
+	}
+	if ctx.GlobalIsSet(OperatorStandbyFlag.Name) {
+		if cfg.OperatorLeaseFile == "" {
+			Fatalf("Flag --%s requires --%s", OperatorStandbyFlag.Name, OperatorLeaseFlag.Name)
+		}
+		cfg.StandbyPrimary = ctx.GlobalString(OperatorStandbyFlag.Name)
+	}
+
+	if ctx.GlobalIsSet(StakingCommitIntervalFlag.Name) {
+		cfg.SeigCommitInterval = ctx.GlobalUint64(StakingCommitIntervalFlag.Name)
+	}
+	if ctx.GlobalIsSet(StakingPowerTONKeeperFlag.Name) {
+		cfg.PowerTONKeeper = ctx.GlobalBool(StakingPowerTONKeeperFlag.Name)
+	}
+
 	if ctx.GlobalIsSet(DeveloperKeyFlag.Name) {
 		devKeys := strings.Split(ctx.GlobalString(DeveloperKeyFlag.Name), ",")
 
@@ -1673,12 +1795,17 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 		}
 	}
 
+	if ctx.GlobalIsSet(RootChainFallbacksFlag.Name) {
+		cfg.RootChainFallbackURLs = splitAndTrim(ctx.GlobalString(RootChainFallbacksFlag.Name))
+	}
+
 	if ctx.GlobalIsSet(RootChainUrlFlag.Name) {
 		cfg.RootChainURL = ctx.GlobalString(RootChainUrlFlag.Name)
-		rootchainBackend, err = ethclient.Dial(cfg.RootChainURL)
+		rootchainBackend, err = failover.Dial(append([]string{cfg.RootChainURL}, cfg.RootChainFallbackURLs...)...)
 		if err != nil {
 			Fatalf("Failed to connect rootchain: %v", err)
 		}
+		defer rootchainBackend.Close()
 
 		rootchainNetworkId, err := rootchainBackend.NetworkID(context.Background())
 		if err != nil {
@@ -1694,20 +1821,7 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 
 	if ctx.GlobalIsSet(OperatorAddressFlag.Name) {
 		operatorAddr = common.HexToAddress(ctx.GlobalString(OperatorAddressFlag.Name))
-		account, err := ks.Find(accounts.Account{Address: operatorAddr})
-
-		if err != nil {
-			Fatalf("Failed to find operator account: %v", err)
-		}
-
-		pwd := readPassword(ctx, ctx.GlobalString(OperatorPasswordFileFlag.Name))
-
-		if err = ks.Unlock(account, pwd); err != nil {
-			Fatalf("Failed to unlock operator account: %v", err)
-		}
-
-		log.Info("Operator account is unlocked", "address", operatorAddr)
-		cfg.Operator = account
+		cfg.Operator = findPlasmaAccount(ctx, stack, ks, operatorAddr, OperatorPasswordFileFlag.Name, "operator")
 		cfg.NodeMode = pls.ModeOperator
 	}
 
@@ -1742,21 +1856,9 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 	if ctx.GlobalIsSet(ChallengerAddressFlag.Name) {
 		hex := ctx.GlobalString(ChallengerAddressFlag.Name)
 		addr := common.HexToAddress(hex)
-		account, err := ks.Find(accounts.Account{Address: addr})
-		pwd := readPassword(ctx, ctx.GlobalString(ChallengerPasswordFileFlag.Name))
+		challenger := findPlasmaAccount(ctx, stack, ks, addr, ChallengerPasswordFileFlag.Name, "challenger")
 
-		if err != nil {
-			Fatalf("Failed to find challenger account: %v", err)
-		}
-
-		if err = ks.Unlock(account, pwd); err != nil {
-			Fatalf("Failed to unlock challenger account: %v", err)
-		}
-		log.Info("Challenger account is unlocked", "address", addr)
-
-		challenger := accounts.Account{Address: addr}
-
-		if cfg.Operator == challenger {
+		if cfg.Operator.Address == challenger.Address {
 			Fatalf("Cannot use same challenger account as operator")
 		}
 
@@ -1860,6 +1962,30 @@ func SetPlsConfig(ctx *cli.Context, stack *node.Node, cfg *pls.Config) {
 
 	cfg.TxConfig.Interval = ctx.Duration(TxResubmitFlag.Name)
 
+	if ctx.GlobalIsSet(TxPipelineFlag.Name) {
+		cfg.TxConfig.Pipeline = ctx.GlobalInt(TxPipelineFlag.Name)
+	}
+
+	if ctx.GlobalIsSet(TxGasPricerFlag.Name) {
+		cfg.TxConfig.GasPricer.Strategy = ctx.GlobalString(TxGasPricerFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxGasPricerEscalationFlag.Name) {
+		cfg.TxConfig.GasPricer.Escalation = ctx.GlobalUint64(TxGasPricerEscalationFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxGasPricerPercentileFlag.Name) {
+		cfg.TxConfig.GasPricer.Percentile = ctx.GlobalInt(TxGasPricerPercentileFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxGasPricerBlocksFlag.Name) {
+		cfg.TxConfig.GasPricer.Blocks = ctx.GlobalUint64(TxGasPricerBlocksFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxGasPricerOverridesFlag.Name) {
+		overrides, err := tx.ParseGasPricerOverrides(ctx.GlobalString(TxGasPricerOverridesFlag.Name))
+		if err != nil {
+			Fatalf("Invalid --%s: %v", TxGasPricerOverridesFlag.Name, err)
+		}
+		cfg.TxConfig.GasPricerOverrides = overrides
+	}
+
 	log.Info("Set options for submitting a block", "mingaspirce", cfg.TxConfig.MinGasPrice, "maxgasprice", cfg.TxConfig.MaxGasPrice, "resubmit", cfg.TxConfig.Interval.String())
 
 	// default operator min ether = 1ether
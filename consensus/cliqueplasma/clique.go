@@ -607,6 +607,17 @@ func (c *Clique) Authorize(signer common.Address, signFn SignerFn) {
 // Seal implements consensus.Engine, attempting to create a sealed block using
 // the local signing credentials.
 func (c *Clique) Seal(chain consensus.ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	return c.seal(chain, block, results, stop, false)
+}
+
+// SealEmpty implements consensus.EmptySealer, attempting to create a sealed
+// block even if it is empty on a 0-period chain. It is used to seal the empty
+// NRBs completing an epoch.
+func (c *Clique) SealEmpty(chain consensus.ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	return c.seal(chain, block, results, stop, true)
+}
+
+func (c *Clique) seal(chain consensus.ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}, empty bool) error {
 	header := block.Header()
 
 	// Sealing the genesis block is not supported
@@ -615,7 +626,7 @@ func (c *Clique) Seal(chain consensus.ChainReader, block *types.Block, results c
 		return errUnknownBlock
 	}
 	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing)
-	if c.config.Period == 0 && len(block.Transactions()) == 0 {
+	if c.config.Period == 0 && len(block.Transactions()) == 0 && !empty {
 		return errWaitTransactions
 	}
 	// Don't hold the signer fields for the entire sealing procedure
@@ -123,3 +123,13 @@ type PoW interface {
 	// Hashrate returns the current mining hashrate of a PoW consensus engine.
 	Hashrate() float64
 }
+
+// EmptySealer is a consensus engine which refuses to seal empty blocks with
+// Seal, but seals them when they are requested explicitly.
+type EmptySealer interface {
+	Engine
+
+	// SealEmpty generates a new sealing request for the given block, even if
+	// the block has no transaction.
+	SealEmpty(chain ChainReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error
+}
@@ -40,12 +40,18 @@ import (
 	"github.com/Onther-Tech/plasma-evm/core"
 	"github.com/Onther-Tech/plasma-evm/core/rawdb"
 	"github.com/Onther-Tech/plasma-evm/core/types"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
 	"github.com/Onther-Tech/plasma-evm/log"
 	"github.com/Onther-Tech/plasma-evm/params"
 )
 
-func DeployPlasmaContracts(opt *bind.TransactOpts, backend *ethclient.Client, staminaConfig *params.StaminaConfig, tonAddress common.Address, withPETH bool, development bool, NRELength *big.Int) (common.Address, *core.Genesis, error) {
+// Backend is the root chain backend to deploy and read the plasma contracts with.
+type Backend interface {
+	bind.ContractBackend
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+}
+
+func DeployPlasmaContracts(opt *bind.TransactOpts, backend Backend, staminaConfig *params.StaminaConfig, tonAddress common.Address, withPETH bool, development bool, NRELength *big.Int) (common.Address, *core.Genesis, error) {
 	operator := opt.From
 	var (
 		tx  *types.Transaction
@@ -157,7 +163,7 @@ func increaseNonce(opt *bind.TransactOpts) {
 
 func DeployManagers(
 	opt *bind.TransactOpts,
-	backend *ethclient.Client,
+	backend Backend,
 	withdrawalDelay *big.Int,
 	seigPerBlock *big.Int,
 	_tonAddr common.Address,
@@ -171,19 +177,14 @@ func DeployManagers(
 	err error,
 ) {
 	var (
-		TON  *ton.TON
 		WTON *wton.WTON
-		//registry       *stakingmanager.RootChainRegistry
-		depositManager *depositmanager.DepositManager
-		//seigManager    *stakingmanager.SeigManager
-
-		tx *types.Transaction
+		tx   *types.Transaction
 	)
 
 	// 1. deploy TON
 	log.Info("1. deploy TON contract")
 	if (_tonAddr == common.Address{}) {
-		if tonAddr, tx, TON, err = ton.DeployTON(opt, backend); err != nil {
+		if tonAddr, tx, _, err = ton.DeployTON(opt, backend); err != nil {
 			err = errors.New(fmt.Sprintf("Failed to deploy TON: %v", err))
 			return
 		}
@@ -198,10 +199,6 @@ func DeployManagers(
 	} else {
 		tonAddr = _tonAddr
 		log.Warn("use already deployed TON", "addr", tonAddr.String())
-		if TON, err = ton.NewTON(tonAddr, backend); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to instantiate TON: %v", err))
-			return
-		}
 	}
 
 	// 2. deploy WTON
@@ -248,7 +245,7 @@ func DeployManagers(
 
 	// 4. deploy DepositManager
 	log.Info("4. deploy DepositManager")
-	if depositManagerAddr, tx, depositManager, err = depositmanager.DeployDepositManager(opt, backend, wtonAddr, registryAddr, withdrawalDelay); err != nil {
+	if depositManagerAddr, tx, _, err = depositmanager.DeployDepositManager(opt, backend, wtonAddr, registryAddr, withdrawalDelay); err != nil {
 		err = errors.New(fmt.Sprintf("Failed to deploy DepositManager: %v", err))
 		return
 	}
@@ -274,18 +271,46 @@ func DeployManagers(
 	log.Info("SeigManager deployed", "addr", seigManagerAddr.String(), "tx", tx.Hash())
 	increaseNonce(opt)
 
+	err = SetupManagers(opt, backend, tonAddr, wtonAddr, depositManagerAddr, seigManagerAddr)
+	return
+}
+
+// SetupManagers grants the minter roles and sets SeigManager to the staking
+// manager contracts. Settings already done are skipped, so it can be run again
+// after a failure.
+func SetupManagers(
+	opt *bind.TransactOpts,
+	backend Backend,
+	tonAddr common.Address,
+	wtonAddr common.Address,
+	depositManagerAddr common.Address,
+	seigManagerAddr common.Address,
+) error {
+	TON, err := ton.NewTON(tonAddr, backend)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to instantiate TON: %v", err))
+	}
+	WTON, err := wton.NewWTON(wtonAddr, backend)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to instantiate WTON: %v", err))
+	}
+	depositManager, err := depositmanager.NewDepositManager(depositManagerAddr, backend)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to instantiate DepositManager: %v", err))
+	}
+
+	var tx *types.Transaction
+
 	// 6. add TON minter role to SeigManager
 	log.Info("6. add WTON minter role to SeigManager")
 	if ok, _ := WTON.IsMinter(&bind.CallOpts{Pending: false}, seigManagerAddr); ok {
 		log.Info("Already minter role provided")
 	} else {
 		if tx, err = WTON.AddMinter(opt, seigManagerAddr); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to add WTON minter role to SeigManager: %v", err))
-			return
+			return errors.New(fmt.Sprintf("Failed to add WTON minter role to SeigManager: %v", err))
 		}
 		if err = WaitTx(backend, tx.Hash()); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to add WTON minter role to SeigManager: %v", err))
-			return
+			return errors.New(fmt.Sprintf("Failed to add WTON minter role to SeigManager: %v", err))
 		}
 		log.Info("Set WTON minter to SeigManager", "tx", tx.Hash())
 		increaseNonce(opt)
@@ -297,12 +322,10 @@ func DeployManagers(
 		log.Info("Already minter role provided")
 	} else {
 		if tx, err = TON.AddMinter(opt, wtonAddr); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to add TON minter role to WTON: %v", err))
-			return
+			return errors.New(fmt.Sprintf("Failed to add TON minter role to WTON: %v", err))
 		}
 		if err = WaitTx(backend, tx.Hash()); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to add TON minter role to WTON: %v", err))
-			return
+			return errors.New(fmt.Sprintf("Failed to add TON minter role to WTON: %v", err))
 		}
 		log.Info("Set TON minter to WTON", "tx", tx.Hash())
 		increaseNonce(opt)
@@ -316,25 +339,28 @@ func DeployManagers(
 	for i, c := range contracts {
 		target := targets[i]
 
+		if addr, _ := c.SeigManager(&bind.CallOpts{Pending: false}); addr == seigManagerAddr {
+			log.Info("Already SeigManager set", "target", target)
+			continue
+		}
+
 		if tx, err = c.SetSeigManager(opt, seigManagerAddr); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to set SeigManager to %s: %v", target, err))
-			return
+			return errors.New(fmt.Sprintf("Failed to set SeigManager to %s: %v", target, err))
 		}
 		if err = WaitTx(backend, tx.Hash()); err != nil {
-			err = errors.New(fmt.Sprintf("Failed to set SeigManager to %s: %v", target, err))
-			return
+			return errors.New(fmt.Sprintf("Failed to set SeigManager to %s: %v", target, err))
 		}
 
 		log.Info("Set SeigManager to target cotnract", "target", target, "tx", tx.Hash())
 		increaseNonce(opt)
 	}
 
-	return
+	return nil
 }
 
 func DeployPowerTON(
 	opt *bind.TransactOpts,
-	backend *ethclient.Client,
+	backend Backend,
 	wtonAddr common.Address,
 	seigManagerAddr common.Address,
 	roundDuration *big.Int,
@@ -393,7 +419,7 @@ func DeployPowerTON(
 	return
 }
 
-func WaitTx(backend *ethclient.Client, hash common.Hash) error {
+func WaitTx(backend Backend, hash common.Hash) error {
 	var receipt *types.Receipt
 
 	<-time.NewTimer(1 * time.Second).C
@@ -0,0 +1,150 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/powerton"
+)
+
+// PowerTONRound is a round of PowerTON.
+type PowerTONRound struct {
+	Round     uint64
+	StartTime uint64
+	EndTime   uint64
+	Reward    *big.Int
+	Winner    common.Address
+	Finished  bool
+}
+
+// PowerTONHistory is the round history of PowerTON.
+type PowerTONHistory struct {
+	PowerTON      common.Address
+	BlockNumber   uint64 // root chain block number the history is read at
+	CurrentRound  uint64
+	RoundDuration uint64 // in seconds
+	Paused        bool
+
+	Rounds []*PowerTONRound // in ascending order
+}
+
+// DefaultPowerTONRounds is the number of recent rounds to read if the first round is not given.
+const DefaultPowerTONRounds = 10
+
+// ReadPowerTONHistory reads rounds of PowerTON from first to last, both
+// inclusive. If last is nil, it reads until the current round. If first is
+// nil, it reads last DefaultPowerTONRounds rounds. Rounds after the current
+// round are ignored.
+func ReadPowerTONHistory(backend Backend, powertonAddr common.Address, first, last *uint64) (*PowerTONHistory, error) {
+	h, opts, err := readPowerTONState(backend, powertonAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := h.RoundRange(first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	pton, err := powerton.NewPowerTON(powertonAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := from; i <= to; i++ {
+		n := new(big.Int).SetUint64(i)
+
+		r, err := pton.Rounds(opts, n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read round #%d: %v", i, err)
+		}
+		finished, err := pton.RoundFinished(opts, n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read whether round #%d is finished: %v", i, err)
+		}
+
+		h.Rounds = append(h.Rounds, &PowerTONRound{
+			Round:     i,
+			StartTime: r.StartTime,
+			EndTime:   r.EndTime,
+			Reward:    r.Reward,
+			Winner:    r.Winner,
+			Finished:  finished,
+		})
+	}
+
+	return h, nil
+}
+
+// ReadPowerTONState reads the current state of PowerTON without rounds.
+func ReadPowerTONState(backend Backend, powertonAddr common.Address) (*PowerTONHistory, error) {
+	h, _, err := readPowerTONState(backend, powertonAddr)
+	return h, err
+}
+
+func readPowerTONState(backend Backend, powertonAddr common.Address) (*PowerTONHistory, *bind.CallOpts, error) {
+	if (powertonAddr == common.Address{}) {
+		return nil, nil, errors.New("PowerTON address is empty")
+	}
+
+	header, err := backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read root chain header: %v", err)
+	}
+
+	// pin all calls to the same block to read a consistent state
+	opts := &bind.CallOpts{Pending: false, BlockNumber: header.Number, Context: context.Background()}
+
+	pton, err := powerton.NewPowerTON(powertonAddr, backend)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currentRound, err := pton.CurrentRound(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read current round: %v", err)
+	}
+	roundDuration, err := pton.RoundDuration(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read round duration: %v", err)
+	}
+	paused, err := pton.Paused(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read paused: %v", err)
+	}
+
+	h := &PowerTONHistory{
+		PowerTON:      powertonAddr,
+		BlockNumber:   header.Number.Uint64(),
+		CurrentRound:  currentRound.Uint64(),
+		RoundDuration: roundDuration.Uint64(),
+		Paused:        paused,
+	}
+	return h, opts, nil
+}
+
+// RoundRange returns the range of the rounds to read from first to last. If
+// last is nil, the range ends at the current round. If first is nil, the range
+// has last DefaultPowerTONRounds rounds.
+func (h *PowerTONHistory) RoundRange(first, last *uint64) (uint64, uint64, error) {
+	to := h.CurrentRound
+	if last != nil && *last < to {
+		to = *last
+	}
+
+	var from uint64
+	if first != nil {
+		from = *first
+	} else if to >= DefaultPowerTONRounds {
+		from = to - DefaultPowerTONRounds + 1
+	}
+
+	if from > to {
+		return 0, 0, fmt.Errorf("invalid round range: %d > %d", from, to)
+	}
+	return from, to, nil
+}
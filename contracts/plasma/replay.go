@@ -0,0 +1,279 @@
+package plasma
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/core"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/core/vm"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+// TxDiff is a difference between a replayed transaction and the transaction
+// at the same index of the local block.
+type TxDiff struct {
+	Index  int
+	Reason string
+
+	Hash    common.Hash // zero if the transaction is not replayed
+	Status  uint64
+	GasUsed uint64
+
+	LocalHash    common.Hash // zero if the local block has no transaction at the index
+	LocalStatus  uint64
+	LocalGasUsed uint64
+}
+
+// BlockReplay is the result of replaying a plasma block.
+type BlockReplay struct {
+	Number    uint64
+	IsRequest bool
+
+	StateRoot   common.Hash
+	TxRoot      common.Hash
+	ReceiptRoot common.Hash
+
+	// roots recorded in RootChain, only for request blocks
+	SubmittedStateRoot   common.Hash
+	SubmittedTxRoot      common.Hash
+	SubmittedReceiptRoot common.Hash
+
+	// roots of the local block, zero if the block is not found
+	LocalStateRoot   common.Hash
+	LocalTxRoot      common.Hash
+	LocalReceiptRoot common.Hash
+
+	TxDiffs []*TxDiff
+}
+
+// Submitted returns whether the roots of the block are recorded in RootChain.
+func (b *BlockReplay) Submitted() bool {
+	return b.SubmittedStateRoot != common.Hash{}
+}
+
+// Matched returns whether the replayed roots equal the submitted roots.
+func (b *BlockReplay) Matched() bool {
+	return b.StateRoot == b.SubmittedStateRoot && b.TxRoot == b.SubmittedTxRoot && b.ReceiptRoot == b.SubmittedReceiptRoot
+}
+
+// EpochReplay is the result of replaying an epoch.
+type EpochReplay struct {
+	ForkNumber       uint64
+	EpochNumber      uint64
+	IsRequest        bool
+	IsEmpty          bool
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+
+	// epoch roots of the replayed blocks
+	StateRoot   common.Hash
+	TxRoot      common.Hash
+	ReceiptRoot common.Hash
+
+	// epoch roots recorded in RootChain, only for non-request epochs
+	SubmittedStateRoot   common.Hash
+	SubmittedTxRoot      common.Hash
+	SubmittedReceiptRoot common.Hash
+
+	Blocks []*BlockReplay
+}
+
+// Matched returns whether the replayed roots equal the submitted roots.
+func (e *EpochReplay) Matched() bool {
+	if e.IsRequest {
+		for _, b := range e.Blocks {
+			if !b.Matched() {
+				return false
+			}
+		}
+		return true
+	}
+	return e.StateRoot == e.SubmittedStateRoot && e.TxRoot == e.SubmittedTxRoot && e.ReceiptRoot == e.SubmittedReceiptRoot
+}
+
+// ReplayEpoch re-executes the epoch on a copy of the local state at the block
+// preceding the epoch. Request transactions are rebuilt from the requests
+// recorded in RootChain, and transactions of non-request blocks are read from
+// the local chain. Nothing is written to the local database.
+func ReplayEpoch(chain *core.BlockChain, contract *rootchain.RootChain, forkNumber, epochNumber uint64) (*EpochReplay, error) {
+	opts := &bind.CallOpts{Pending: false, Context: context.Background()}
+	fork, epochNum := new(big.Int).SetUint64(forkNumber), new(big.Int).SetUint64(epochNumber)
+
+	epoch, err := contract.GetEpoch(opts, fork, epochNum)
+	if err != nil {
+		return nil, err
+	}
+	if !epoch.Initialized {
+		return nil, fmt.Errorf("epoch#%d of fork#%d is not prepared", epochNumber, forkNumber)
+	}
+
+	r := &EpochReplay{
+		ForkNumber:           forkNumber,
+		EpochNumber:          epochNumber,
+		IsRequest:            epoch.IsRequest,
+		IsEmpty:              epoch.IsEmpty,
+		StartBlockNumber:     epoch.StartBlockNumber,
+		EndBlockNumber:       epoch.EndBlockNumber,
+		SubmittedStateRoot:   epoch.NRE.EpochStateRoot,
+		SubmittedTxRoot:      epoch.NRE.EpochTransactionsRoot,
+		SubmittedReceiptRoot: epoch.NRE.EpochReceiptsRoot,
+	}
+	if epoch.IsEmpty {
+		return r, nil
+	}
+
+	var bodies []types.Transactions
+	if epoch.IsRequest {
+		requestGas, err := contract.REQUESTGAS(opts)
+		if err != nil {
+			return nil, err
+		}
+		if bodies, err = RequestBlockBodies(contract, opts, fork, epochNum, requestGas.Uint64()); err != nil {
+			return nil, err
+		}
+	}
+
+	if epoch.StartBlockNumber == 0 {
+		return nil, fmt.Errorf("epoch#%d of fork#%d has no parent block", epochNumber, forkNumber)
+	}
+	parent := chain.GetBlockByNumber(epoch.StartBlockNumber - 1)
+	if parent == nil {
+		return nil, fmt.Errorf("block #%d is not found", epoch.StartBlockNumber-1)
+	}
+	statedb, err := chain.StateAt(parent.Root())
+	if err != nil {
+		return nil, fmt.Errorf("state of block #%d is not available: %v", parent.NumberU64(), err)
+	}
+
+	var replayed types.Blocks
+	for number := epoch.StartBlockNumber; number <= epoch.EndBlockNumber; number++ {
+		local := chain.GetBlockByNumber(number)
+
+		var (
+			header *types.Header
+			txs    types.Transactions
+		)
+		if local != nil {
+			header = types.CopyHeader(local.Header())
+		} else {
+			// assemble the header as the miner does for the missing block
+			header = &types.Header{
+				ParentHash: parent.Hash(),
+				Number:     new(big.Int).SetUint64(number),
+				GasLimit:   parent.GasLimit(),
+				Time:       parent.Time() + 1,
+				Difficulty: parent.Difficulty(),
+				Coinbase:   params.NullAddress,
+			}
+		}
+
+		b := &BlockReplay{Number: number, IsRequest: epoch.IsRequest}
+		if epoch.IsRequest {
+			txs = bodies[number-epoch.StartBlockNumber]
+
+			submitted, err := contract.GetBlock(opts, fork, new(big.Int).SetUint64(number))
+			if err != nil {
+				return nil, err
+			}
+			b.SubmittedStateRoot = submitted.StatesRoot
+			b.SubmittedTxRoot = submitted.TransactionsRoot
+			b.SubmittedReceiptRoot = submitted.ReceiptsRoot
+		} else {
+			if local == nil {
+				return nil, fmt.Errorf("non-request block #%d is not found", number)
+			}
+			txs = local.Transactions()
+		}
+
+		var (
+			applied  types.Transactions
+			receipts types.Receipts
+			usedGas  = new(uint64)
+			gp       = new(core.GasPool).AddGas(header.GasLimit)
+		)
+		for i, tx := range txs {
+			statedb.Prepare(tx.Hash(), common.Hash{}, len(applied))
+			receipt, err := core.ApplyTransaction(chain.Config(), chain, nil, gp, statedb, header, tx, usedGas, vm.Config{})
+			if err != nil {
+				b.TxDiffs = append(b.TxDiffs, &TxDiff{Index: i, Reason: fmt.Sprintf("failed to apply: %v", err), Hash: tx.Hash()})
+				continue
+			}
+			applied = append(applied, tx)
+			receipts = append(receipts, receipt)
+		}
+		chain.Engine().Finalize(chain, header, statedb, applied, nil)
+
+		header.Root = statedb.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+		header.GasUsed = *usedGas
+		block := types.NewBlock(header, applied, nil, receipts)
+
+		b.StateRoot = block.Root()
+		b.TxRoot = block.TxHash()
+		b.ReceiptRoot = block.ReceiptHash()
+
+		if local != nil {
+			b.LocalStateRoot = local.Root()
+			b.LocalTxRoot = local.TxHash()
+			b.LocalReceiptRoot = local.ReceiptHash()
+			b.TxDiffs = append(b.TxDiffs, DiffTransactions(applied, receipts, local.Transactions(), chain.GetReceiptsByHash(local.Hash()))...)
+		}
+
+		r.Blocks = append(r.Blocks, b)
+		replayed = append(replayed, block)
+		parent = block
+	}
+
+	r.StateRoot = replayed.StatesRoot()
+	r.TxRoot = replayed.TransactionsRoot()
+	r.ReceiptRoot = replayed.ReceiptssRoot()
+
+	return r, nil
+}
+
+// DiffTransactions compares replayed transactions and receipts with the local
+// ones index by index, and returns the differences.
+func DiffTransactions(txs types.Transactions, receipts types.Receipts, localTxs types.Transactions, localReceipts types.Receipts) []*TxDiff {
+	var diffs []*TxDiff
+
+	n := len(txs)
+	if len(localTxs) > n {
+		n = len(localTxs)
+	}
+	for i := 0; i < n; i++ {
+		d := &TxDiff{Index: i}
+		if i < len(txs) {
+			d.Hash = txs[i].Hash()
+			if i < len(receipts) {
+				d.Status, d.GasUsed = receipts[i].Status, receipts[i].GasUsed
+			}
+		}
+		if i < len(localTxs) {
+			d.LocalHash = localTxs[i].Hash()
+			if i < len(localReceipts) {
+				d.LocalStatus, d.LocalGasUsed = localReceipts[i].Status, localReceipts[i].GasUsed
+			}
+		}
+
+		switch {
+		case i >= len(txs):
+			d.Reason = "missing in replay"
+		case i >= len(localTxs):
+			d.Reason = "missing in local block"
+		case d.Hash != d.LocalHash:
+			d.Reason = "transaction mismatch"
+		case d.Status != d.LocalStatus:
+			d.Reason = "status mismatch"
+		case d.GasUsed != d.LocalGasUsed:
+			d.Reason = "gas used mismatch"
+		default:
+			continue
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
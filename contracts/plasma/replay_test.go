@@ -0,0 +1,47 @@
+package plasma
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+)
+
+func TestDiffTransactions(t *testing.T) {
+	newTx := func(nonce uint64) *types.Transaction {
+		return types.NewTransaction(nonce, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	}
+	newReceipt := func(status, gasUsed uint64) *types.Receipt {
+		return &types.Receipt{Status: status, GasUsed: gasUsed}
+	}
+
+	txs := types.Transactions{newTx(0), newTx(1), newTx(2), newTx(3)}
+	receipts := types.Receipts{newReceipt(1, 21000), newReceipt(1, 21000), newReceipt(0, 30000), newReceipt(1, 21000)}
+
+	localTxs := types.Transactions{newTx(0), newTx(9), newTx(2), newTx(3), newTx(4)}
+	localReceipts := types.Receipts{newReceipt(1, 21000), newReceipt(1, 21000), newReceipt(1, 30000), newReceipt(1, 25000), newReceipt(1, 21000)}
+
+	diffs := DiffTransactions(txs, receipts, localTxs, localReceipts)
+
+	want := []struct {
+		index  int
+		reason string
+	}{
+		{1, "transaction mismatch"},
+		{2, "status mismatch"},
+		{3, "gas used mismatch"},
+		{4, "missing in replay"},
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("number of diffs mismatch: have %d, want %d", len(diffs), len(want))
+	}
+	for i, w := range want {
+		if diffs[i].Index != w.index || diffs[i].Reason != w.reason {
+			t.Errorf("diff %d mismatch: have (%d, %q), want (%d, %q)", i, diffs[i].Index, diffs[i].Reason, w.index, w.reason)
+		}
+	}
+	if (diffs[3].Hash != common.Hash{}) || diffs[3].LocalHash != localTxs[4].Hash() {
+		t.Errorf("missing transaction hashes mismatch: have (%x, %x)", diffs[3].Hash, diffs[3].LocalHash)
+	}
+}
@@ -0,0 +1,118 @@
+package plasma
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi"
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+var requestableContractABI, _ = abi.JSON(strings.NewReader(rootchain.RequestableIABI))
+
+// RequestTxGasLimit returns the gas limit of a request transaction. Requests
+// are applied with the REQUESTGAS budget of RootChain, including ether enters,
+// so that the child chain applies them as RootChain accounts them.
+func RequestTxGasLimit(requestGas uint64) uint64 {
+	if requestGas == 0 {
+		return params.RequestTxGasLimit
+	}
+	return requestGas
+}
+
+// RequestBlockBodies reads the requests of each ORB of the request epoch from
+// RootChain and builds the request transactions applying them in the child
+// chain, in block order.
+func RequestBlockBodies(contract *rootchain.RootChain, opts *bind.CallOpts, forkNumber, epochNumber *big.Int, requestGas uint64) ([]types.Transactions, error) {
+	epoch, err := contract.GetEpoch(opts, forkNumber, epochNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !epoch.IsRequest {
+		return nil, fmt.Errorf("epoch#%d is not a request epoch", epochNumber.Uint64())
+	}
+	if epoch.IsEmpty {
+		return nil, nil
+	}
+
+	// TODO: URE, ORE' should handle requestBlockId in a different way.
+	requestBlockId := epoch.RE.FirstRequestBlockId
+	bodies := make([]types.Transactions, 0, epoch.EndBlockNumber-epoch.StartBlockNumber+1)
+
+	log.Debug("Num Orbs", "epochNumber", epochNumber, "numORBs", cap(bodies), "requestBlockId", requestBlockId, "e.EndBlockNumber", epoch.EndBlockNumber, "e.StartBlockNumber", epoch.StartBlockNumber)
+	for blockNumber := epoch.StartBlockNumber; blockNumber <= epoch.EndBlockNumber; blockNumber++ {
+		begin := time.Now()
+
+		orb, err := contract.ORBs(opts, new(big.Int).SetUint64(requestBlockId))
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Fetching ORB", "blockNumber", blockNumber, "requestStart", orb.RequestStart, "requestEnd", orb.RequestEnd, "requestBlockId", requestBlockId)
+
+		body := make(types.Transactions, 0, orb.RequestEnd-orb.RequestStart+1)
+		for requestId := orb.RequestStart; requestId <= orb.RequestEnd; requestId++ {
+			requestTx, err := requestTransaction(contract, opts, requestId, requestGas)
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, requestTx)
+		}
+		log.Info("Request txs fetched", "blockNumber", blockNumber, "requestBlockId", requestBlockId, "numRequests", len(body), "elapsed", time.Since(begin))
+
+		bodies = append(bodies, body)
+		requestBlockId++
+	}
+
+	return bodies, nil
+}
+
+// requestTransaction builds the request transaction applying the ERO in the
+// child chain.
+func requestTransaction(contract *rootchain.RootChain, opts *bind.CallOpts, requestId uint64, requestGas uint64) (*types.Transaction, error) {
+	request, err := contract.EROs(opts, new(big.Int).SetUint64(requestId))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Request fetched", "requestId", requestId, "hash", common.Bytes2Hex(request.Hash[:]), "request", request)
+
+	var (
+		to    common.Address
+		value *big.Int
+		input []byte
+	)
+
+	if request.IsTransfer && !request.IsExit {
+		to = request.Requestor
+		value = new(big.Int).SetBytes(request.TrieValue[:])
+	} else {
+		to, _ = contract.RequestableContracts(opts, request.To)
+		value = request.Value
+		input, err = requestableContractABI.Pack("applyRequestInChildChain",
+			request.IsExit,
+			new(big.Int).SetUint64(requestId),
+			request.Requestor,
+			request.TrieKey,
+			request.TrieValue,
+		)
+		if err != nil {
+			log.Error("Failed to pack applyRequestInChildChain", "err", err)
+		}
+
+		log.Debug("Request tx.data", "payload", common.Bytes2Hex(input))
+	}
+
+	gasLimit := RequestTxGasLimit(requestGas)
+	requestTx := types.NewTransaction(0, to, value, gasLimit, params.RequestTxGasPrice, input)
+
+	log.Debug("Request Transaction", "tx", requestTx)
+
+	return requestTx, nil
+}
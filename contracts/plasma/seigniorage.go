@@ -0,0 +1,269 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/seigmanager"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/ton"
+)
+
+var (
+	ray     = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
+	halfRay = new(big.Int).Div(ray, big.NewInt(2))
+)
+
+// SeigProjection describes the seigniorage a root chain (and optionally one of
+// its delegators) receives if the root chain commits Horizon blocks after
+// BlockNumber. All token amounts are in WTON (RAY).
+type SeigProjection struct {
+	RootChain   common.Address
+	SeigManager common.Address
+	PowerTON    common.Address
+	Operator    common.Address
+
+	BlockNumber     uint64 // root chain block number the projection is based on
+	Horizon         uint64 // number of blocks to project after BlockNumber
+	LastSeigBlock   uint64
+	LastCommitBlock uint64
+	Paused          bool
+
+	SeigPerBlock        *big.Int
+	CommissionRate      *big.Int
+	PowerTONNumerator   *big.Int
+	PowerTONDenominator *big.Int
+
+	TotalStake     *big.Int // total supply of tot
+	TotalSupply    *big.Int // total supply of (W)TON considered by SeigManager
+	RootChainStake *big.Int // tot balance of the root chain
+	CommittedStake *big.Int // total supply of the root chain's coinage
+
+	// seigniorage given to all root chains until the horizon
+	MaxSeig      *big.Int
+	StakedSeig   *big.Int
+	UnstakedSeig *big.Int
+	PowerTONSeig *big.Int
+
+	// seigniorage of the root chain which is not committed yet
+	UncommittedSeig *big.Int
+	CommissionSeig  *big.Int
+	DelegatorsSeig  *big.Int
+
+	// seigniorage of a single delegator, if requested
+	Account      *common.Address
+	AccountStake *big.Int
+	AccountSeig  *big.Int
+}
+
+// ProjectSeigniorage reads SeigManager state from root chain and projects the
+// uncommitted seigniorage of the root chain at horizon blocks after the latest
+// root chain block. If account is not nil, the share of the account is projected too.
+func ProjectSeigniorage(
+	backend Backend,
+	seigManagerAddr common.Address,
+	rootchainAddr common.Address,
+	account *common.Address,
+	horizon uint64,
+) (*SeigProjection, error) {
+	if (seigManagerAddr == common.Address{}) {
+		return nil, errors.New("SeigManager address is empty")
+	}
+
+	header, err := backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root chain header: %v", err)
+	}
+
+	// pin all calls to the same block to read a consistent state
+	opts := &bind.CallOpts{Pending: false, BlockNumber: header.Number, Context: context.Background()}
+
+	seigManager, err := seigmanager.NewSeigManager(seigManagerAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	rootchainContract, err := rootchain.NewRootChain(rootchainAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &SeigProjection{
+		RootChain:   rootchainAddr,
+		SeigManager: seigManagerAddr,
+		BlockNumber: header.Number.Uint64(),
+		Horizon:     horizon,
+		Account:     account,
+	}
+
+	var (
+		totAddr     common.Address
+		coinageAddr common.Address
+		tonAddr     common.Address
+		wtonAddr    common.Address
+
+		lastSeigBlock   *big.Int
+		lastCommitBlock *big.Int
+	)
+
+	if p.Operator, err = rootchainContract.Operator(opts); err != nil {
+		return nil, fmt.Errorf("failed to read operator: %v", err)
+	}
+	if p.PowerTON, err = seigManager.Powerton(opts); err != nil {
+		return nil, fmt.Errorf("failed to read PowerTON address: %v", err)
+	}
+	if p.Paused, err = seigManager.Paused(opts); err != nil {
+		return nil, fmt.Errorf("failed to read paused: %v", err)
+	}
+	if p.SeigPerBlock, err = seigManager.SeigPerBlock(opts); err != nil {
+		return nil, fmt.Errorf("failed to read seigniorage per block: %v", err)
+	}
+	if p.CommissionRate, err = seigManager.CommissionRates(opts, rootchainAddr); err != nil {
+		return nil, fmt.Errorf("failed to read commission rate: %v", err)
+	}
+	if p.PowerTONNumerator, err = seigManager.POWERTONNUMERATOR(opts); err != nil {
+		return nil, fmt.Errorf("failed to read PowerTON numerator: %v", err)
+	}
+	if p.PowerTONDenominator, err = seigManager.POWERTONDENOMINATOR(opts); err != nil {
+		return nil, fmt.Errorf("failed to read PowerTON denominator: %v", err)
+	}
+	if lastSeigBlock, err = seigManager.LastSeigBlock(opts); err != nil {
+		return nil, fmt.Errorf("failed to read last seigniorage block: %v", err)
+	}
+	if lastCommitBlock, err = seigManager.LastCommitBlock(opts, rootchainAddr); err != nil {
+		return nil, fmt.Errorf("failed to read last commit block: %v", err)
+	}
+	p.LastSeigBlock = lastSeigBlock.Uint64()
+	p.LastCommitBlock = lastCommitBlock.Uint64()
+
+	if totAddr, err = seigManager.Tot(opts); err != nil {
+		return nil, fmt.Errorf("failed to read tot address: %v", err)
+	}
+	if coinageAddr, err = seigManager.Coinages(opts, rootchainAddr); err != nil {
+		return nil, fmt.Errorf("failed to read coinage address: %v", err)
+	}
+	if (coinageAddr == common.Address{}) {
+		return nil, fmt.Errorf("root chain %s is not registered to SeigManager", rootchainAddr.Hex())
+	}
+	if tonAddr, err = seigManager.Ton(opts); err != nil {
+		return nil, fmt.Errorf("failed to read TON address: %v", err)
+	}
+	if wtonAddr, err = seigManager.Wton(opts); err != nil {
+		return nil, fmt.Errorf("failed to read WTON address: %v", err)
+	}
+
+	tot, err := seigmanager.NewERC20(totAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	coinage, err := seigmanager.NewERC20(coinageAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	TON, err := ton.NewTON(tonAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.TotalStake, err = tot.TotalSupply(opts); err != nil {
+		return nil, fmt.Errorf("failed to read total stake: %v", err)
+	}
+	if p.RootChainStake, err = tot.BalanceOf(opts, rootchainAddr); err != nil {
+		return nil, fmt.Errorf("failed to read stake of root chain: %v", err)
+	}
+	if p.CommittedStake, err = coinage.TotalSupply(opts); err != nil {
+		return nil, fmt.Errorf("failed to read committed stake of root chain: %v", err)
+	}
+
+	tonTotalSupply, err := TON.TotalSupply(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TON total supply: %v", err)
+	}
+	tonBalanceOfWTON, err := TON.BalanceOf(opts, wtonAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TON balance of WTON: %v", err)
+	}
+
+	// total supply of (W)TON = (TON total supply - TON balance of WTON) in RAY + total supply of tot
+	p.TotalSupply = new(big.Int).Sub(tonTotalSupply, tonBalanceOfWTON)
+	p.TotalSupply.Mul(p.TotalSupply, big.NewInt(1e9))
+	p.TotalSupply.Add(p.TotalSupply, p.TotalStake)
+
+	if account != nil {
+		if p.AccountStake, err = seigManager.StakeOf(opts, rootchainAddr, *account); err != nil {
+			return nil, fmt.Errorf("failed to read stake of account: %v", err)
+		}
+	}
+
+	p.calc()
+
+	return p, nil
+}
+
+// calc fills seigniorage fields of the projection in the same way
+// SeigManager gives seigniorage to tot and then to coinage of the root chain.
+func (p *SeigProjection) calc() {
+	var numSeigBlocks uint64
+	if target := p.BlockNumber + p.Horizon; !p.Paused && target > p.LastSeigBlock {
+		numSeigBlocks = target - p.LastSeigBlock
+	}
+
+	p.MaxSeig = new(big.Int).Mul(p.SeigPerBlock, new(big.Int).SetUint64(numSeigBlocks))
+	p.StakedSeig = new(big.Int)
+	if p.TotalSupply.Sign() > 0 {
+		p.StakedSeig = rdiv(rmul(p.MaxSeig, p.TotalStake), p.TotalSupply)
+	}
+	p.UnstakedSeig = new(big.Int).Sub(p.MaxSeig, p.StakedSeig)
+
+	p.PowerTONSeig = new(big.Int)
+	if (p.PowerTON != common.Address{}) && p.PowerTONDenominator.Sign() > 0 {
+		p.PowerTONSeig = rmul(p.UnstakedSeig, rdiv(p.PowerTONNumerator, p.PowerTONDenominator))
+	}
+
+	// tot balance of the root chain increases at the same rate as total supply of tot.
+	nextRootChainStake := new(big.Int).Set(p.RootChainStake)
+	if p.TotalStake.Sign() > 0 {
+		increased := new(big.Int).Mul(p.RootChainStake, p.StakedSeig)
+		increased.Div(increased, p.TotalStake)
+		nextRootChainStake.Add(nextRootChainStake, increased)
+	}
+
+	p.UncommittedSeig = new(big.Int)
+	if nextRootChainStake.Cmp(p.CommittedStake) > 0 {
+		p.UncommittedSeig.Sub(nextRootChainStake, p.CommittedStake)
+	}
+
+	p.CommissionSeig = new(big.Int)
+	if p.CommissionRate.Sign() != 0 {
+		p.CommissionSeig = rmul(p.UncommittedSeig, p.CommissionRate)
+	}
+	p.DelegatorsSeig = new(big.Int).Sub(p.UncommittedSeig, p.CommissionSeig)
+
+	if p.Account != nil {
+		p.AccountSeig = new(big.Int)
+		if p.CommittedStake.Sign() > 0 {
+			p.AccountSeig.Mul(p.DelegatorsSeig, p.AccountStake)
+			p.AccountSeig.Div(p.AccountSeig, p.CommittedStake)
+		}
+		if *p.Account == p.Operator {
+			p.AccountSeig.Add(p.AccountSeig, p.CommissionSeig)
+		}
+	}
+}
+
+// rmul multiplies two RAY values with rounding.
+func rmul(x, y *big.Int) *big.Int {
+	z := new(big.Int).Mul(x, y)
+	z.Add(z, halfRay)
+	return z.Div(z, ray)
+}
+
+// rdiv divides two RAY values with rounding.
+func rdiv(x, y *big.Int) *big.Int {
+	z := new(big.Int).Mul(x, ray)
+	z.Add(z, new(big.Int).Div(y, big.NewInt(2)))
+	return z.Div(z, y)
+}
@@ -0,0 +1,76 @@
+package plasma
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+)
+
+func rayOf(v int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(v), ray)
+}
+
+func TestSeigProjection(t *testing.T) {
+	operator := common.HexToAddress("0x01")
+	delegator := common.HexToAddress("0x02")
+
+	newProjection := func(account common.Address) *SeigProjection {
+		return &SeigProjection{
+			PowerTON:            common.HexToAddress("0x03"),
+			Operator:            operator,
+			BlockNumber:         100,
+			Horizon:             10,
+			LastSeigBlock:       90,
+			SeigPerBlock:        rayOf(10),
+			CommissionRate:      new(big.Int).Div(ray, big.NewInt(10)), // 10%
+			PowerTONNumerator:   big.NewInt(1),
+			PowerTONDenominator: big.NewInt(2),
+			TotalStake:          rayOf(1000),
+			TotalSupply:         rayOf(4000),
+			RootChainStake:      rayOf(500),
+			CommittedStake:      rayOf(500),
+			Account:             &account,
+			AccountStake:        rayOf(100),
+		}
+	}
+
+	p := newProjection(delegator)
+	p.calc()
+
+	// 20 blocks since last seigniorage block, a quarter of total supply is staked.
+	checks := []struct {
+		name string
+		have *big.Int
+		want *big.Int
+	}{
+		{"max", p.MaxSeig, rayOf(200)},
+		{"staked", p.StakedSeig, rayOf(50)},
+		{"unstaked", p.UnstakedSeig, rayOf(150)},
+		{"powerton", p.PowerTONSeig, rayOf(75)},
+		{"uncommitted", p.UncommittedSeig, rayOf(25)},
+		{"commission", p.CommissionSeig, new(big.Int).Div(rayOf(25), big.NewInt(10))},
+		{"delegators", p.DelegatorsSeig, new(big.Int).Div(rayOf(225), big.NewInt(10))},
+		{"account", p.AccountSeig, new(big.Int).Div(rayOf(45), big.NewInt(10))},
+	}
+	for _, c := range checks {
+		if c.have.Cmp(c.want) != 0 {
+			t.Errorf("%s seigniorage mismatch: have %v, want %v", c.name, c.have, c.want)
+		}
+	}
+
+	// operator receives commission in addition to its own share.
+	p = newProjection(operator)
+	p.calc()
+	if want := new(big.Int).Div(rayOf(70), big.NewInt(10)); p.AccountSeig.Cmp(want) != 0 {
+		t.Errorf("operator seigniorage mismatch: have %v, want %v", p.AccountSeig, want)
+	}
+
+	// no seigniorage is given while SeigManager is paused.
+	p = newProjection(delegator)
+	p.Paused = true
+	p.calc()
+	if p.MaxSeig.Sign() != 0 || p.UncommittedSeig.Sign() != 0 {
+		t.Errorf("seigniorage given while paused: max %v, uncommitted %v", p.MaxSeig, p.UncommittedSeig)
+	}
+}
@@ -0,0 +1,128 @@
+package plasma
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/depositmanager"
+)
+
+// WithdrawalRequest is a withdrawal request of DepositManager.
+type WithdrawalRequest struct {
+	Index                   uint64
+	Amount                  *big.Int
+	WithdrawableBlockNumber uint64
+	Processed               bool
+	Processable             bool // whether the request can be processed in the next root chain block
+}
+
+// WithdrawalQueue is the queue of withdrawal requests of an account for a root chain.
+type WithdrawalQueue struct {
+	RootChain      common.Address
+	DepositManager common.Address
+	Account        common.Address
+
+	BlockNumber     uint64 // root chain block number the queue is read at
+	WithdrawalDelay uint64
+	NumRequests     uint64 // total number of requests, including processed ones
+	NextIndex       uint64 // index of the next request to process
+
+	Requests          []*WithdrawalRequest // requests not processed yet, in processing order
+	NumProcessable    uint64
+	PendingAmount     *big.Int
+	ProcessableAmount *big.Int
+}
+
+// ReadWithdrawalQueue reads pending withdrawal requests of the account for the
+// root chain from DepositManager.
+func ReadWithdrawalQueue(
+	backend Backend,
+	depositManagerAddr common.Address,
+	rootchainAddr common.Address,
+	account common.Address,
+) (*WithdrawalQueue, error) {
+	if (depositManagerAddr == common.Address{}) {
+		return nil, errors.New("DepositManager address is empty")
+	}
+
+	header, err := backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root chain header: %v", err)
+	}
+
+	// pin all calls to the same block to read a consistent state
+	opts := &bind.CallOpts{Pending: false, BlockNumber: header.Number, Context: context.Background()}
+
+	depositManager, err := depositmanager.NewDepositManager(depositManagerAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &WithdrawalQueue{
+		RootChain:         rootchainAddr,
+		DepositManager:    depositManagerAddr,
+		Account:           account,
+		BlockNumber:       header.Number.Uint64(),
+		PendingAmount:     new(big.Int),
+		ProcessableAmount: new(big.Int),
+	}
+
+	delay, err := depositManager.WITHDRAWALDELAY(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read withdrawal delay: %v", err)
+	}
+	numRequests, err := depositManager.NumRequests(opts, rootchainAddr, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read number of requests: %v", err)
+	}
+	index, err := depositManager.WithdrawalRequestIndex(opts, rootchainAddr, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read withdrawal request index: %v", err)
+	}
+
+	q.WithdrawalDelay = delay.Uint64()
+	q.NumRequests = numRequests.Uint64()
+	q.NextIndex = index.Uint64()
+
+	for i := q.NextIndex; i < q.NumRequests; i++ {
+		r, err := depositManager.WithdrawalRequest(opts, rootchainAddr, account, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read withdrawal request #%d: %v", i, err)
+		}
+
+		request := &WithdrawalRequest{
+			Index:                   i,
+			Amount:                  r.Amount,
+			WithdrawableBlockNumber: r.WithdrawableBlockNumber.Uint64(),
+			Processed:               r.Processed,
+		}
+		if request.Processed {
+			continue
+		}
+
+		q.Requests = append(q.Requests, request)
+		q.PendingAmount.Add(q.PendingAmount, request.Amount)
+	}
+
+	q.markProcessable()
+
+	return q, nil
+}
+
+// markProcessable marks matured requests as processable. Requests are processed
+// in order, so only the matured requests at the front of the queue can be processed.
+func (q *WithdrawalQueue) markProcessable() {
+	for _, r := range q.Requests {
+		if r.WithdrawableBlockNumber > q.BlockNumber {
+			break
+		}
+
+		r.Processable = true
+		q.NumProcessable++
+		q.ProcessableAmount.Add(q.ProcessableAmount, r.Amount)
+	}
+}
@@ -0,0 +1,34 @@
+package plasma
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestWithdrawalQueueProcessable(t *testing.T) {
+	q := &WithdrawalQueue{
+		BlockNumber: 100,
+		Requests: []*WithdrawalRequest{
+			{Index: 3, Amount: big.NewInt(1), WithdrawableBlockNumber: 90},
+			{Index: 4, Amount: big.NewInt(2), WithdrawableBlockNumber: 100},
+			{Index: 5, Amount: big.NewInt(4), WithdrawableBlockNumber: 110},
+			// matured, but cannot be processed before the previous request
+			{Index: 6, Amount: big.NewInt(8), WithdrawableBlockNumber: 95},
+		},
+		ProcessableAmount: new(big.Int),
+	}
+
+	q.markProcessable()
+
+	if q.NumProcessable != 2 {
+		t.Errorf("number of processable requests mismatch: have %d, want %d", q.NumProcessable, 2)
+	}
+	if q.ProcessableAmount.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("processable amount mismatch: have %v, want %v", q.ProcessableAmount, 3)
+	}
+	for i, want := range []bool{true, true, false, false} {
+		if q.Requests[i].Processable != want {
+			t.Errorf("request #%d processable mismatch: have %v, want %v", q.Requests[i].Index, q.Requests[i].Processable, want)
+		}
+	}
+}
@@ -495,6 +495,13 @@ func (bc *BlockChain) SetRootchainBlockNumber(num uint64) {
 	}
 }
 
+// SetRootchainCheckpoint stores the last root chain block whose events are all
+// processed. Unlike the rootchain block number, it moves back on root chain
+// reorgs.
+func (bc *BlockChain) SetRootchainCheckpoint(num uint64, hash common.Hash) {
+	rawdb.WriteRootchainCheckpoint(bc.db, num, hash)
+}
+
 // FastSyncCommitHead sets the current head block to the one defined by the hash
 // irrelevant what the chain contents were prior.
 func (bc *BlockChain) FastSyncCommitHead(hash common.Hash) error {
@@ -802,6 +809,12 @@ func (bc *BlockChain) GetInvalidExitReceipts(fork uint64, num uint64) map[uint64
 	return ierc
 }
 
+// GetRootchainCheckpoint retrieves the last root chain block whose events are
+// all processed.
+func (bc *BlockChain) GetRootchainCheckpoint() (uint64, common.Hash, bool) {
+	return rawdb.ReadRootchainCheckpoint(bc.db)
+}
+
 // GetRootchainBlockNumber retrieves a block number for rootchain contract event.
 func (bc *BlockChain) GetRootchainBlockNumber() uint64 {
 	num := rawdb.ReadRootchainBlockNumber(bc.db)
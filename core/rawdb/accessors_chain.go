@@ -83,6 +83,24 @@ func WriteRootchainBlockNumber(db ethdb.KeyValueWriter, number uint64) {
 	}
 }
 
+// ReadRootchainCheckpoint returns the number and hash of the last root chain
+// block whose events are all processed.
+func ReadRootchainCheckpoint(db ethdb.Reader) (uint64, common.Hash, bool) {
+	data, _ := db.Get(rootchainCheckpointKey)
+	if len(data) != 8+common.HashLength {
+		return 0, common.Hash{}, false
+	}
+	return binary.BigEndian.Uint64(data[:8]), common.BytesToHash(data[8:]), true
+}
+
+// WriteRootchainCheckpoint stores the number and hash of the last root chain
+// block whose events are all processed.
+func WriteRootchainCheckpoint(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	if err := db.Put(rootchainCheckpointKey, append(encodeBlockNumber(number), hash.Bytes()...)); err != nil {
+		log.Crit("Failed to store rootchain checkpoint", "err", err)
+	}
+}
+
 // ReadAllHashes retrieves all the hashes assigned to blocks at a certain heights,
 // both canonical and reorged forks included.
 func ReadAllHashes(db ethdb.Iteratee, number uint64) []common.Hash {
@@ -817,3 +835,27 @@ func ReadPowerTON(db ethdb.Reader) common.Address {
 	}
 	return *addr
 }
+
+func WriteRootChain(db ethdb.KeyValueWriter, addr common.Address) {
+	data, err := rlp.EncodeToBytes(addr)
+	if err != nil {
+		log.Crit("Failed to RLP encode RootChain address", "err", err)
+	}
+
+	if err := db.Put(rootchainKey, data); err != nil {
+		log.Crit("Failed to store RootChain address", "err", err)
+	}
+}
+
+func ReadRootChain(db ethdb.Reader) common.Address {
+	data, _ := db.Get(rootchainKey)
+	if len(data) == 0 {
+		return common.Address{}
+	}
+	addr := new(common.Address)
+	if err := rlp.Decode(bytes.NewReader(data), addr); err != nil {
+		log.Error("Invalid address RLP", "addr", addr, "err", err)
+		return common.Address{}
+	}
+	return *addr
+}
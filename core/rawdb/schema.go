@@ -44,6 +44,10 @@ var (
 	// rootchainBlockNumberKey tracks the number of root chain block.
 	rootchainBlockNumberKey = []byte("RootChainBlockNumber")
 
+	// rootchainCheckpointKey tracks the number and hash of the last root chain
+	// block whose events are all processed.
+	rootchainCheckpointKey = []byte("RootChainCheckpoint")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -68,6 +72,7 @@ var (
 	depositrManagerKey = []byte("DepositManager-address")
 	seigManagerKey     = []byte("SeigManager-address")
 	powertonKey        = []byte("PowerTON-address")
+	rootchainKey       = []byte("RootChain-address")
 
 	preimagePrefix = []byte("secure-key-")      // preimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-") // config prefix for the db
@@ -0,0 +1,597 @@
+// Package failover provides a root chain client over multiple providers. It
+// checks the health of the providers, switches to a healthy one when the
+// current provider stops responding and broadcasts transactions to all healthy
+// providers.
+package failover
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/event"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+)
+
+var (
+	// HealthCheckInterval is the interval between health checks of the providers.
+	HealthCheckInterval = 3 * time.Second
+
+	// HealthCheckTimeout is the timeout of a single health check.
+	HealthCheckTimeout = 5 * time.Second
+)
+
+var (
+	ErrNoProvider       = errors.New("no root chain provider")
+	ErrProviderSwitched = errors.New("root chain provider switched")
+	errNotConnected     = errors.New("root chain provider not connected")
+)
+
+// Status is the last health check result of a provider.
+type Status struct {
+	URL       string
+	Alive     bool
+	Latency   time.Duration
+	Err       error
+	CheckedAt time.Time
+}
+
+type provider struct {
+	url    string
+	client *ethclient.Client // nil until dialed
+
+	status Status
+}
+
+// Client is a root chain client over an ordered list of providers. Calls go to
+// the current provider and are retried on the next one if the provider fails
+// to respond. Subscriptions fail with ErrProviderSwitched when the current
+// provider changes, so that subscribers re-subscribe on the new provider.
+type Client struct {
+	providers []*provider
+	current   int
+
+	// closed when the current provider changes
+	switched chan struct{}
+
+	lock sync.RWMutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Dial connects to the providers in the order of preference. Providers which
+// cannot be dialed are dialed again by the health check, but at least one
+// provider must be reachable.
+func Dial(urls ...string) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoProvider
+	}
+
+	clients := make([]*ethclient.Client, len(urls))
+	var lastErr error
+	for i, url := range urls {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			log.Warn("Failed to connect root chain provider", "url", url, "err", err)
+			lastErr = err
+			continue
+		}
+		clients[i] = client
+	}
+
+	c := NewClient(urls, clients)
+	if _, err := c.currentClient(); err != nil {
+		c.Close()
+		return nil, lastErr
+	}
+	return c, nil
+}
+
+// NewClient creates a client over the already connected providers. A nil
+// client is dialed by the health check.
+func NewClient(urls []string, clients []*ethclient.Client) *Client {
+	c := &Client{
+		providers: make([]*provider, len(urls)),
+		current:   -1,
+		switched:  make(chan struct{}),
+		quit:      make(chan struct{}),
+	}
+
+	for i, url := range urls {
+		c.providers[i] = &provider{
+			url:    url,
+			client: clients[i],
+			status: Status{URL: url, Alive: clients[i] != nil},
+		}
+	}
+	for i, p := range c.providers {
+		if p.client != nil {
+			c.current = i
+			break
+		}
+	}
+
+	c.wg.Add(1)
+	go c.loop()
+
+	return c
+}
+
+// Close stops the health check and closes the connections to the providers.
+func (c *Client) Close() {
+	select {
+	case <-c.quit:
+		return
+	default:
+		close(c.quit)
+	}
+	c.wg.Wait()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, p := range c.providers {
+		if p.client != nil {
+			p.client.Close()
+		}
+	}
+}
+
+// Status returns the status of the current provider.
+func (c *Client) Status() Status {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.current < 0 {
+		return Status{Err: errNotConnected}
+	}
+	return c.providers[c.current].status
+}
+
+// Statuses returns the status of all providers in the order of preference.
+func (c *Client) Statuses() []Status {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	statuses := make([]Status, len(c.providers))
+	for i, p := range c.providers {
+		statuses[i] = p.status
+	}
+	return statuses
+}
+
+func (c *Client) loop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkHealth()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// checkHealth checks all providers in parallel and switches to the most
+// preferred healthy provider if the current one is down.
+func (c *Client) checkHealth() {
+	c.lock.RLock()
+	providers := c.providers
+	c.lock.RUnlock()
+
+	statuses := make([]Status, len(providers))
+	clients := make([]*ethclient.Client, len(providers))
+
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func(i int, p *provider) {
+			defer wg.Done()
+
+			c.lock.RLock()
+			client := p.client
+			c.lock.RUnlock()
+
+			ctx, cancel := context.WithTimeout(context.Background(), HealthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			if client == nil {
+				rpcClient, err := rpc.DialContext(ctx, p.url)
+				if err != nil {
+					statuses[i] = Status{URL: p.url, Err: err, CheckedAt: start}
+					return
+				}
+				client = ethclient.NewClient(rpcClient)
+				clients[i] = client
+			}
+			_, err := client.SyncProgress(ctx)
+			statuses[i] = Status{
+				URL:       p.url,
+				Alive:     err == nil,
+				Latency:   time.Since(start),
+				Err:       err,
+				CheckedAt: start,
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, p := range providers {
+		if clients[i] != nil {
+			p.client = clients[i]
+		}
+		if !statuses[i].Alive && p.status.Alive {
+			log.Error("Root chain provider doesn't respond", "url", p.url, "err", statuses[i].Err)
+		} else if statuses[i].Alive && !p.status.Alive {
+			log.Info("Root chain provider recovered", "url", p.url)
+		}
+		p.status = statuses[i]
+	}
+
+	if c.current < 0 || !providers[c.current].status.Alive {
+		for i, p := range providers {
+			if p.status.Alive {
+				c.switchTo(i)
+				break
+			}
+		}
+	}
+}
+
+// switchTo changes the current provider. The caller must hold the lock.
+func (c *Client) switchTo(i int) {
+	if i == c.current {
+		return
+	}
+
+	var from string
+	if c.current >= 0 {
+		from = c.providers[c.current].url
+	}
+	log.Warn("Switching root chain provider", "from", from, "to", c.providers[i].url)
+
+	c.current = i
+	close(c.switched)
+	c.switched = make(chan struct{})
+	switchMeter.Mark(1)
+}
+
+// fail marks the provider down and switches to the next provider, preferring
+// a healthy one.
+func (c *Client) fail(client *ethclient.Client, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.current < 0 || c.providers[c.current].client != client {
+		return // already switched
+	}
+
+	cur := c.providers[c.current]
+	log.Error("Root chain provider failed", "url", cur.url, "err", err)
+	cur.status.Alive = false
+	cur.status.Err = err
+
+	n := len(c.providers)
+	next := -1
+	for i := 1; i < n; i++ {
+		p := c.providers[(c.current+i)%n]
+		if p.client == nil {
+			continue
+		}
+		if p.status.Alive {
+			next = (c.current + i) % n
+			break
+		}
+		if next < 0 {
+			next = (c.current + i) % n
+		}
+	}
+	if next >= 0 {
+		c.switchTo(next)
+	}
+}
+
+func (c *Client) currentClient() (*ethclient.Client, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.current < 0 || c.providers[c.current].client == nil {
+		return nil, errNotConnected
+	}
+	return c.providers[c.current].client, nil
+}
+
+// do calls fn with the current provider, and retries it on the other
+// providers if the provider fails to respond.
+func (c *Client) do(fn func(client *ethclient.Client) error) error {
+	var err error
+	for i := 0; i < len(c.providers); i++ {
+		var client *ethclient.Client
+		if client, err = c.currentClient(); err != nil {
+			return err
+		}
+		if err = fn(client); !isConnectionError(err) {
+			return err
+		}
+		c.fail(client, err)
+	}
+	return err
+}
+
+// isConnectionError reports whether err means the provider failed to respond
+// rather than the provider rejected the request.
+func isConnectionError(err error) bool {
+	if err == nil || err == ethereum.NotFound || err == context.Canceled {
+		return false
+	}
+	if _, ok := err.(rpc.Error); ok {
+		return false
+	}
+	return true
+}
+
+// subscribe wraps the subscription of the current provider so that it fails
+// when the current provider changes.
+func (c *Client) subscribe(fn func(client *ethclient.Client) (ethereum.Subscription, error)) (ethereum.Subscription, error) {
+	var (
+		sub      ethereum.Subscription
+		switched chan struct{}
+	)
+	err := c.do(func(client *ethclient.Client) error {
+		c.lock.RLock()
+		switched = c.switched
+		c.lock.RUnlock()
+
+		var err error
+		sub, err = fn(client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+
+		select {
+		case err := <-sub.Err():
+			return err
+		case <-switched:
+			return ErrProviderSwitched
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+// ChainID retrieves the current chain ID for transaction replay protection.
+func (c *Client) ChainID(ctx context.Context) (id *big.Int, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		id, err = client.ChainID(ctx)
+		return err
+	})
+	return id, err
+}
+
+// NetworkID returns the network ID of the root chain.
+func (c *Client) NetworkID(ctx context.Context) (id *big.Int, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		id, err = client.NetworkID(ctx)
+		return err
+	})
+	return id, err
+}
+
+// BlockByHash returns the given full block.
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (block *types.Block, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		block, err = client.BlockByHash(ctx, hash)
+		return err
+	})
+	return block, err
+}
+
+// BlockByNumber returns a block from the current canonical chain. If number is
+// nil, the latest known block is returned.
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (block *types.Block, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		block, err = client.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If
+// number is nil, the latest known header is returned.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// TransactionReceipt returns the receipt of a transaction by transaction hash.
+func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (receipt *types.Receipt, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		receipt, err = client.TransactionReceipt(ctx, hash)
+		return err
+	})
+	return receipt, err
+}
+
+// SyncProgress retrieves the current progress of the sync algorithm.
+func (c *Client) SyncProgress(ctx context.Context) (progress *ethereum.SyncProgress, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		progress, err = client.SyncProgress(ctx)
+		return err
+	})
+	return progress, err
+}
+
+// SubscribeNewHead subscribes to notifications about the current blockchain head.
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return c.subscribe(func(client *ethclient.Client) (ethereum.Subscription, error) {
+		return client.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// BalanceAt returns the wei balance of the given account.
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		balance, err = client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// CodeAt returns the contract code of the given account.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		code, err = client.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// NonceAt returns the account nonce of the given account.
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		nonce, err = client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// FilterLogs executes a filter query.
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		logs, err = client.FilterLogs(ctx, q)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query.
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return c.subscribe(func(client *ethclient.Client) (ethereum.Subscription, error) {
+		return client.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		code, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// CallContract executes a message call transaction on the root chain.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		result, err = client.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price.
+func (c *Client) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// EstimateGas estimates the gas needed to execute a specific transaction.
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	err = c.do(func(client *ethclient.Client) error {
+		gas, err = client.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction broadcasts the transaction to the current provider and all
+// the other healthy providers. The result of the current provider is returned
+// unless it fails to respond, in which case the transaction is sent if any
+// other provider accepted it.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.lock.RLock()
+	var (
+		current = -1
+		clients []*ethclient.Client
+	)
+	for i, p := range c.providers {
+		if p.client == nil || (i != c.current && !p.status.Alive) {
+			continue
+		}
+		if i == c.current {
+			current = len(clients)
+		}
+		clients = append(clients, p.client)
+	}
+	c.lock.RUnlock()
+
+	if current < 0 {
+		return errNotConnected
+	}
+
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client *ethclient.Client) {
+			defer wg.Done()
+			errs[i] = client.SendTransaction(ctx, tx)
+		}(i, client)
+	}
+	wg.Wait()
+
+	broadcastMeter.Mark(int64(len(clients)))
+
+	if !isConnectionError(errs[current]) {
+		return errs[current]
+	}
+	c.fail(clients[current], errs[current])
+
+	err := errs[current]
+	for i, e := range errs {
+		if i == current || isConnectionError(e) {
+			continue
+		}
+		if e == nil {
+			return nil
+		}
+		err = e
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package failover
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+)
+
+type testService struct {
+	chainId int64
+	sent    int32
+}
+
+func (s *testService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(s.chainId))
+}
+
+func (s *testService) Syncing() bool {
+	return false
+}
+
+func (s *testService) SendRawTransaction(data hexutil.Bytes) common.Hash {
+	atomic.AddInt32(&s.sent, 1)
+	return common.Hash{}
+}
+
+func newTestProvider(t *testing.T, chainId int64) (*testService, *httptest.Server) {
+	service := &testService{chainId: chainId}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	return service, httptest.NewServer(server)
+}
+
+func TestFailover(t *testing.T) {
+	primary, primaryServer := newTestProvider(t, 1)
+	fallback, fallbackServer := newTestProvider(t, 2)
+	defer fallbackServer.Close()
+
+	client, err := Dial(primaryServer.URL, fallbackServer.URL)
+	if err != nil {
+		t.Fatalf("failed to dial providers: %v", err)
+	}
+	defer client.Close()
+
+	if id, err := client.ChainID(context.Background()); err != nil || id.Int64() != 1 {
+		t.Fatalf("chain id mismatch: have %v (%v), want %d", id, err, 1)
+	}
+
+	// transactions are sent to all healthy providers
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	if err := client.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	if primary.sent != 1 || fallback.sent != 1 {
+		t.Fatalf("broadcast mismatch: have %d/%d, want 1/1", primary.sent, fallback.sent)
+	}
+
+	// calls are retried on the fallback provider if the primary one is down
+	primaryServer.Close()
+	if id, err := client.ChainID(context.Background()); err != nil || id.Int64() != 2 {
+		t.Fatalf("chain id mismatch after failover: have %v (%v), want %d", id, err, 2)
+	}
+	if status := client.Status(); status.URL != fallbackServer.URL {
+		t.Fatalf("current provider mismatch: have %s, want %s", status.URL, fallbackServer.URL)
+	}
+	if statuses := client.Statuses(); statuses[0].Alive || !statuses[1].Alive {
+		t.Fatalf("provider status mismatch: %+v", statuses)
+	}
+
+	if err := client.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("failed to send transaction after failover: %v", err)
+	}
+	if fallback.sent != 2 {
+		t.Fatalf("sent transactions mismatch: have %d, want %d", fallback.sent, 2)
+	}
+}
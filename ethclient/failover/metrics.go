@@ -0,0 +1,8 @@
+package failover
+
+import "github.com/Onther-Tech/plasma-evm/metrics"
+
+var (
+	switchMeter    = metrics.NewRegisteredMeter("rootchain/provider/switch", nil)
+	broadcastMeter = metrics.NewRegisteredMeter("rootchain/provider/broadcast", nil)
+)
@@ -481,6 +481,20 @@ type blockStats struct {
 	TxHash     common.Hash    `json:"transactionsRoot"`
 	Root       common.Hash    `json:"stateRoot"`
 	Uncles     uncleStats     `json:"uncles"`
+
+	Plasma *plasmaBlockStats `json:"plasma,omitempty"`
+}
+
+// plasmaBlockStats is the information to report about how a plasma block is
+// committed to the root chain.
+type plasmaBlockStats struct {
+	IsRequest      bool     `json:"isRequest"`
+	ForkNumber     *big.Int `json:"forkNumber"`
+	EpochNumber    uint64   `json:"epochNumber"`
+	RequestBlockId uint64   `json:"requestBlockId"`
+	Submitted      bool     `json:"submitted"`
+	Finalized      bool     `json:"finalized"`
+	Challenged     bool     `json:"challenged"`
 }
 
 // txStats is the information to report about individual transactions.
@@ -553,6 +567,11 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 	// Assemble and return the block stats
 	author, _ := s.engine.Author(header)
 
+	var plasma *plasmaBlockStats
+	if s.pls != nil {
+		plasma = s.assemblePlasmaBlockStats(block)
+	}
+
 	return &blockStats{
 		Number:     header.Number,
 		Hash:       header.Hash(),
@@ -567,9 +586,44 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 		TxHash:     header.TxHash,
 		Root:       header.Root,
 		Uncles:     uncles,
+		Plasma:     plasma,
 	}
 }
 
+// assemblePlasmaBlockStats reads the plasma block recorded in the root chain
+// and assembles its commitment stats. Fields from the root chain are left
+// empty if it is not reachable.
+func (s *Service) assemblePlasmaBlockStats(block *types.Block) *plasmaBlockStats {
+	stats := &plasmaBlockStats{IsRequest: block.IsRequest()}
+
+	rcm := s.pls.RootChainManager()
+	if rcm == nil || block.NumberU64() == 0 {
+		return stats
+	}
+
+	// blocks read back from the database do not carry their fork, so the fork
+	// followed by the node is used for them
+	forkNumber := block.CurrentFork()
+	if forkNumber == 0 {
+		forkNumber = rcm.CurrentFork()
+	}
+
+	b, err := rcm.PlasmaBlock(forkNumber, block.NumberU64())
+	if err != nil {
+		log.Debug("Failed to read plasma block from root chain", "number", block.Number(), "err", err)
+		return stats
+	}
+
+	stats.ForkNumber = new(big.Int).SetUint64(forkNumber)
+	stats.EpochNumber = b.EpochNumber
+	stats.RequestBlockId = b.RequestBlockId
+	stats.Submitted = b.Timestamp != 0
+	stats.Finalized = b.Finalized
+	stats.Challenged = b.Challenged
+
+	return stats
+}
+
 // reportHistory retrieves the most recent batch of blocks and reports it to the
 // stats server.
 func (s *Service) reportHistory(conn *websocket.Conn, list []uint64) error {
@@ -670,6 +724,17 @@ type nodeStats struct {
 	Peers    int  `json:"peers"`
 	GasPrice int  `json:"gasPrice"`
 	Uptime   int  `json:"uptime"`
+
+	Plasma *plasmaNodeStats `json:"plasma,omitempty"`
+}
+
+// plasmaNodeStats is the information to report about the role of the local
+// node and its root chain provider.
+type plasmaNodeStats struct {
+	Role             string `json:"role"`
+	RootChainAlive   *bool  `json:"rootchainAlive,omitempty"` // nil until the first health check
+	RootChainLatency int64  `json:"rootchainLatency"`         // in milliseconds
+	RootChainError   string `json:"rootchainError,omitempty"`
 }
 
 // reportPending retrieves various stats about the node at the networking and
@@ -681,6 +746,7 @@ func (s *Service) reportStats(conn *websocket.Conn) error {
 		hashrate int
 		syncing  bool
 		gasprice int
+		plasma   *plasmaNodeStats
 	)
 	if s.pls != nil {
 		mining = s.pls.Miner().Mining()
@@ -692,6 +758,17 @@ func (s *Service) reportStats(conn *websocket.Conn) error {
 
 		price, _ := s.pls.APIBackend.SuggestPrice(context.Background())
 		gasprice = int(price.Uint64())
+
+		plasma = &plasmaNodeStats{Role: pls.NodeModeName(s.pls.NodeMode())}
+		if rcm := s.pls.RootChainManager(); rcm != nil {
+			if status := rcm.BackendStatus(); !status.CheckedAt.IsZero() {
+				plasma.RootChainAlive = &status.Alive
+				plasma.RootChainLatency = int64(status.Latency / time.Millisecond)
+				if status.Err != nil {
+					plasma.RootChainError = status.Err.Error()
+				}
+			}
+		}
 	} else {
 		sync := s.les.Downloader().Progress()
 		syncing = s.les.BlockChain().CurrentHeader().Number.Uint64() >= sync.HighestBlock
@@ -709,6 +786,7 @@ func (s *Service) reportStats(conn *websocket.Conn) error {
 			GasPrice: gasprice,
 			Syncing:  syncing,
 			Uptime:   100,
+			Plasma:   plasma,
 		},
 	}
 	report := map[string][]interface{}{
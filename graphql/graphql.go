@@ -20,11 +20,13 @@ package graphql
 import (
 	"context"
 	"errors"
+	"math/big"
 	"time"
 
 	ethereum "github.com/Onther-Tech/plasma-evm"
 	"github.com/Onther-Tech/plasma-evm/common"
 	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
 	"github.com/Onther-Tech/plasma-evm/core/rawdb"
 	"github.com/Onther-Tech/plasma-evm/core/state"
 	"github.com/Onther-Tech/plasma-evm/core/types"
@@ -334,6 +336,9 @@ type Block struct {
 	block        *types.Block
 	receipts     []*types.Receipt
 	canonical    BlockType // Indicates if this block is on the main chain or not.
+
+	plasmaFork  *big.Int                   // current fork number of RootChain
+	plasmaBlock *rootchain.DataPlasmaBlock // block recorded in RootChain
 }
 
 func (b *Block) onMainChain(ctx context.Context) error {
@@ -0,0 +1,287 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/internal/ethapi"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+)
+
+var errNoRootChain = errors.New("root chain is not available")
+
+// RootChainBackend is implemented by backends which can read RootChain
+// contract. Plasma fields are resolved only if the backend implements it.
+type RootChainBackend interface {
+	RootChainContract() *rootchain.RootChain
+}
+
+func rootchainOf(backend ethapi.Backend) *rootchain.RootChain {
+	if b, ok := backend.(RootChainBackend); ok {
+		return b.RootChainContract()
+	}
+	return nil
+}
+
+func callOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Pending: false, Context: ctx}
+}
+
+// Epoch represents a plasma epoch recorded in RootChain contract.
+type Epoch struct {
+	backend   ethapi.Backend
+	rootchain *rootchain.RootChain
+	fork      uint64
+	number    uint64
+	epoch     rootchain.DataEpoch
+}
+
+func (e *Epoch) Fork() hexutil.Uint64             { return hexutil.Uint64(e.fork) }
+func (e *Epoch) Number() hexutil.Uint64           { return hexutil.Uint64(e.number) }
+func (e *Epoch) StartBlockNumber() hexutil.Uint64 { return hexutil.Uint64(e.epoch.StartBlockNumber) }
+func (e *Epoch) EndBlockNumber() hexutil.Uint64   { return hexutil.Uint64(e.epoch.EndBlockNumber) }
+func (e *Epoch) Timestamp() hexutil.Uint64        { return hexutil.Uint64(e.epoch.Timestamp) }
+func (e *Epoch) IsEmpty() bool                    { return e.epoch.IsEmpty }
+func (e *Epoch) IsRequest() bool                  { return e.epoch.IsRequest }
+func (e *Epoch) UserActivated() bool              { return e.epoch.UserActivated }
+func (e *Epoch) IsRebase() bool                   { return e.epoch.Rebase }
+
+func (e *Epoch) Finalized(ctx context.Context) (bool, error) {
+	last, err := e.rootchain.GetLastFinalizedEpoch(callOpts(ctx), new(big.Int).SetUint64(e.fork))
+	if err != nil {
+		return false, err
+	}
+	return e.epoch.Initialized && last.Uint64() >= e.number, nil
+}
+
+func (e *Epoch) Blocks(ctx context.Context) []*Block {
+	if e.epoch.IsEmpty || e.epoch.EndBlockNumber < e.epoch.StartBlockNumber {
+		return []*Block{}
+	}
+	ret := make([]*Block, 0, e.epoch.EndBlockNumber-e.epoch.StartBlockNumber+1)
+	for i := e.epoch.StartBlockNumber; i <= e.epoch.EndBlockNumber; i++ {
+		numberOrHash := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(i))
+		ret = append(ret, &Block{
+			backend:      e.backend,
+			numberOrHash: &numberOrHash,
+		})
+	}
+	return ret
+}
+
+func (e *Epoch) Requests(ctx context.Context) ([]*Request, error) {
+	if !e.epoch.IsRequest || e.epoch.IsEmpty || e.epoch.RE.RequestEnd < e.epoch.RE.RequestStart {
+		return []*Request{}, nil
+	}
+	ret := make([]*Request, 0, e.epoch.RE.RequestEnd-e.epoch.RE.RequestStart+1)
+	for i := e.epoch.RE.RequestStart; i <= e.epoch.RE.RequestEnd; i++ {
+		r, err := readRequest(ctx, e.rootchain, i, e.epoch.UserActivated)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+// requestData is the request recorded in RootChain contract.
+type requestData struct {
+	Timestamp  uint64
+	IsExit     bool
+	IsTransfer bool
+	Finalized  bool
+	Challenged bool
+	Value      *big.Int
+	Requestor  common.Address
+	To         common.Address
+	TrieKey    [32]byte
+	Hash       [32]byte
+	TrieValue  []byte
+}
+
+// Request represents an enter or exit request recorded in RootChain contract.
+type Request struct {
+	id            uint64
+	userActivated bool
+	data          requestData
+}
+
+func readRequest(ctx context.Context, rc *rootchain.RootChain, id uint64, userActivated bool) (*Request, error) {
+	var (
+		data requestData
+		err  error
+	)
+	if userActivated {
+		r, e := rc.ERUs(callOpts(ctx), new(big.Int).SetUint64(id))
+		data, err = requestData(r), e
+	} else {
+		r, e := rc.EROs(callOpts(ctx), new(big.Int).SetUint64(id))
+		data, err = requestData(r), e
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Request{id: id, userActivated: userActivated, data: data}, nil
+}
+
+func (r *Request) Id() hexutil.Uint64        { return hexutil.Uint64(r.id) }
+func (r *Request) UserActivated() bool       { return r.userActivated }
+func (r *Request) Timestamp() hexutil.Uint64 { return hexutil.Uint64(r.data.Timestamp) }
+func (r *Request) IsExit() bool              { return r.data.IsExit }
+func (r *Request) IsTransfer() bool          { return r.data.IsTransfer }
+func (r *Request) Requestor() common.Address { return r.data.Requestor }
+func (r *Request) To() common.Address        { return r.data.To }
+func (r *Request) TrieKey() common.Hash      { return common.Hash(r.data.TrieKey) }
+func (r *Request) TrieValue() hexutil.Bytes  { return hexutil.Bytes(r.data.TrieValue) }
+func (r *Request) Hash() common.Hash         { return common.Hash(r.data.Hash) }
+func (r *Request) Finalized() bool           { return r.data.Finalized }
+func (r *Request) Challenged() bool          { return r.data.Challenged }
+
+func (r *Request) Value() hexutil.Big {
+	if r.data.Value == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*r.data.Value)
+}
+
+func (r *Request) Status() string {
+	switch {
+	case r.data.Challenged:
+		return "challenged"
+	case r.data.Finalized:
+		return "finalized"
+	default:
+		return "pending"
+	}
+}
+
+// resolvePlasma returns the current fork number and the plasma block of this
+// block recorded in RootChain contract, fetching them if necessary. It returns
+// a nil fork number if the root chain is not available.
+func (b *Block) resolvePlasma(ctx context.Context) (*big.Int, *rootchain.DataPlasmaBlock, error) {
+	if b.plasmaBlock != nil {
+		return b.plasmaFork, b.plasmaBlock, nil
+	}
+	rc := rootchainOf(b.backend)
+	if rc == nil {
+		return nil, nil, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, nil, err
+	}
+	fork, err := rc.CurrentFork(callOpts(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := rc.GetBlock(callOpts(ctx), fork, header.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	b.plasmaFork, b.plasmaBlock = fork, &block
+	return b.plasmaFork, b.plasmaBlock, nil
+}
+
+func (b *Block) IsRequest(ctx context.Context) (bool, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return false, err
+	}
+	return block.IsRequest(), nil
+}
+
+func (b *Block) Fork(ctx context.Context) (*hexutil.Uint64, error) {
+	fork, _, err := b.resolvePlasma(ctx)
+	if err != nil || fork == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(fork.Uint64())
+	return &ret, nil
+}
+
+func (b *Block) Submitted(ctx context.Context) (*bool, error) {
+	fork, block, err := b.resolvePlasma(ctx)
+	if err != nil || fork == nil {
+		return nil, err
+	}
+	ret := block.Timestamp != 0
+	return &ret, nil
+}
+
+func (b *Block) Finalized(ctx context.Context) (*bool, error) {
+	fork, block, err := b.resolvePlasma(ctx)
+	if err != nil || fork == nil {
+		return nil, err
+	}
+	ret := block.Finalized
+	return &ret, nil
+}
+
+func (b *Block) Epoch(ctx context.Context) (*Epoch, error) {
+	fork, block, err := b.resolvePlasma(ctx)
+	if err != nil || fork == nil || block.Timestamp == 0 {
+		return nil, err
+	}
+	return readEpoch(ctx, b.backend, fork.Uint64(), block.EpochNumber)
+}
+
+func readEpoch(ctx context.Context, backend ethapi.Backend, fork, number uint64) (*Epoch, error) {
+	rc := rootchainOf(backend)
+	if rc == nil {
+		return nil, errNoRootChain
+	}
+	epoch, err := rc.GetEpoch(callOpts(ctx), new(big.Int).SetUint64(fork), new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, err
+	}
+	if !epoch.Initialized {
+		return nil, nil
+	}
+	return &Epoch{
+		backend:   backend,
+		rootchain: rc,
+		fork:      fork,
+		number:    number,
+		epoch:     epoch,
+	}, nil
+}
+
+func (r *Resolver) Epoch(ctx context.Context, args struct {
+	Number hexutil.Uint64
+	Fork   *hexutil.Uint64
+}) (*Epoch, error) {
+	rc := rootchainOf(r.backend)
+	if rc == nil {
+		return nil, errNoRootChain
+	}
+	var fork uint64
+	if args.Fork != nil {
+		fork = uint64(*args.Fork)
+	} else {
+		current, err := rc.CurrentFork(callOpts(ctx))
+		if err != nil {
+			return nil, err
+		}
+		fork = current.Uint64()
+	}
+	return readEpoch(ctx, r.backend, fork, uint64(args.Number))
+}
+
+func (r *Resolver) Request(ctx context.Context, args struct {
+	Id            hexutil.Uint64
+	UserActivated *bool
+}) (*Request, error) {
+	rc := rootchainOf(r.backend)
+	if rc == nil {
+		return nil, errNoRootChain
+	}
+	userActivated := args.UserActivated != nil && *args.UserActivated
+
+	// reading out of range request reverts
+	return readRequest(ctx, rc, uint64(args.Id), userActivated)
+}
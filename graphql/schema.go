@@ -206,6 +206,82 @@ const schema string = `
         # EstimateGas estimates the amount of gas that will be required for
         # successful execution of a transaction at the current block's state.
         estimateGas(data: CallData!): Long!
+
+        # IsRequest is true if this block is a request block.
+        isRequest: Boolean!
+        # Fork is the current fork number of RootChain contract. This will be
+        # null if the root chain is not available.
+        fork: Long
+        # Epoch is the epoch this block belongs to. This will be null if the
+        # block is not submitted or the root chain is not available.
+        epoch: Epoch
+        # Submitted is true if this block is submitted to RootChain contract.
+        # This will be null if the root chain is not available.
+        submitted: Boolean
+        # Finalized is true if this block is finalized in RootChain contract.
+        # This will be null if the root chain is not available.
+        finalized: Boolean
+    }
+
+    # Epoch is a plasma epoch recorded in RootChain contract.
+    type Epoch {
+        # Fork is the fork number of this epoch.
+        fork: Long!
+        # Number is the number of this epoch in the fork.
+        number: Long!
+        # StartBlockNumber is the number of the first block of this epoch.
+        startBlockNumber: Long!
+        # EndBlockNumber is the number of the last block of this epoch.
+        endBlockNumber: Long!
+        # Timestamp is the root chain timestamp at which this epoch was prepared.
+        timestamp: Long!
+        # IsEmpty is true if this epoch has no block.
+        isEmpty: Boolean!
+        # IsRequest is true if this epoch is a request epoch.
+        isRequest: Boolean!
+        # UserActivated is true if this epoch is for user-activated requests.
+        userActivated: Boolean!
+        # IsRebase is true if this epoch is rebased after a fork.
+        isRebase: Boolean!
+        # Finalized is true if this epoch is finalized in RootChain contract.
+        finalized: Boolean!
+        # Blocks is a list of blocks of this epoch.
+        blocks: [Block!]!
+        # Requests is a list of requests applied in this epoch. It is empty for
+        # non-request epochs.
+        requests: [Request!]!
+    }
+
+    # Request is an enter or exit request recorded in RootChain contract.
+    type Request {
+        # Id is the request id. Ids of ERO and ERU are counted separately.
+        id: Long!
+        # UserActivated is true if this request is an ERU, false if an ERO.
+        userActivated: Boolean!
+        # Timestamp is the root chain timestamp at which this request was made.
+        timestamp: Long!
+        # IsExit is true for exit requests, false for enter requests.
+        isExit: Boolean!
+        # IsTransfer is true if this request transfers ether only.
+        isTransfer: Boolean!
+        # Requestor is the account that made this request.
+        requestor: Address!
+        # To is the requestable contract of this request.
+        to: Address!
+        # Value is the value, in wei, of this request.
+        value: BigInt!
+        # TrieKey is the key of the requestable contract to update.
+        trieKey: Bytes32!
+        # TrieValue is the value of the requestable contract to update.
+        trieValue: Bytes!
+        # Hash is the hash of this request.
+        hash: Bytes32!
+        # Finalized is true if this request is finalized.
+        finalized: Boolean!
+        # Challenged is true if this request is challenged.
+        challenged: Boolean!
+        # Status is one of "pending", "finalized" and "challenged".
+        status: String!
     }
 
     # CallData represents the data associated with a local contract call.
@@ -311,6 +387,11 @@ const schema string = `
         protocolVersion: Int!
         # Syncing returns information on the current synchronisation state.
         syncing: SyncState
+        # Epoch returns a plasma epoch. If fork is not supplied, it defaults to
+        # the current fork.
+        epoch(number: Long!, fork: Long): Epoch
+        # Request returns an ERO, or an ERU if userActivated is true.
+        request(id: Long!, userActivated: Boolean): Request
     }
 
     type Mutation {
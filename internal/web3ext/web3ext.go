@@ -33,6 +33,11 @@ var Modules = map[string]string{
 	"swarmfs":    SwarmfsJs,
 	"txpool":     TxpoolJs,
 	"les":        LESJs,
+	"plasma":     PlasmaJs,
+	"stamina":    StaminaJs,
+	"staking":    StakingJs,
+	"standby":    StandbyJs,
+	"txManager":  TxManagerJs,
 }
 
 const ChequebookJs = `
@@ -60,7 +65,11 @@ web3._extend({
 			name: 'issue',
 			call: 'chequebook_issue',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 	]
 });
@@ -173,7 +182,9 @@ web3._extend({
 			name: 'exportChain',
 			call: 'admin_exportChain',
 			params: 3,
-			inputFormatter: [null, null, null]
+			inputFormatter: [null, null, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'importChain',
@@ -205,6 +216,38 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'reconcileRootChainNonces',
+			call: 'admin_reconcileRootChainNonces'
+		}),
+		new web3._extend.Method({
+			name: 'rootChainCosts',
+			call: 'admin_rootChainCosts',
+			params: 2,
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
+		}),
+		new web3._extend.Method({
+			name: 'rootChainDailyCosts',
+			call: 'admin_rootChainDailyCosts',
+			params: 2,
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
+		}),
+		new web3._extend.Method({
+			name: 'exportRootChainCosts',
+			call: 'admin_exportRootChainCosts',
+			params: 3,
+			inputFormatter: [null, null, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -219,6 +262,14 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'rootChainProviders',
+			getter: 'admin_rootChainProviders'
+		}),
+		new web3._extend.Property({
+			name: 'rootChainNonces',
+			getter: 'admin_rootChainNonces'
+		}),
 	]
 });
 `
@@ -383,13 +434,21 @@ web3._extend({
 			name: 'traceBlock',
 			call: 'debug_traceBlock',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'traceBlockFromFile',
 			call: 'debug_traceBlockFromFile',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'traceBadBlock',
@@ -401,31 +460,51 @@ web3._extend({
 			name: 'standardTraceBadBlockToFile',
 			call: 'debug_standardTraceBadBlockToFile',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'standardTraceBlockToFile',
 			call: 'debug_standardTraceBlockToFile',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'traceBlockByNumber',
 			call: 'debug_traceBlockByNumber',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'traceBlockByHash',
 			call: 'debug_traceBlockByHash',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'traceTransaction',
 			call: 'debug_traceTransaction',
 			params: 2,
-			inputFormatter: [null, null]
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
 		}),
 		new web3._extend.Method({
 			name: 'preimage',
@@ -447,7 +526,11 @@ web3._extend({
 			name: 'getModifiedAccountsByNumber',
 			call: 'debug_getModifiedAccountsByNumber',
 			params: 2,
-			inputFormatter: [null, null],
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}],
 		}),
 		new web3._extend.Method({
 			name: 'getModifiedAccountsByHash',
@@ -856,3 +939,224 @@ web3._extend({
 	]
 });
 `
+
+const PlasmaJs = `
+web3._extend({
+	property: 'plasma',
+	methods: [
+		new web3._extend.Method({
+			name: 'getEpoch',
+			call: 'plasma_getEpoch',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, function(fork) {
+				// the current fork is used if omitted
+				return fork === undefined || fork === null ? null : web3._extend.utils.fromDecimal(fork);
+			}]
+		}),
+		new web3._extend.Method({
+			name: 'getBlock',
+			call: 'plasma_getBlock',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, function(fork) {
+				// the current fork is used if omitted
+				return fork === undefined || fork === null ? null : web3._extend.utils.fromDecimal(fork);
+			}]
+		}),
+		new web3._extend.Method({
+			name: 'getRequest',
+			call: 'plasma_getRequest',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getPendingRequests',
+			call: 'plasma_pendingRequests',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getExits',
+			call: 'plasma_getExits',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getReceiptProof',
+			call: 'plasma_getReceiptProof',
+			params: 1
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'rootchain',
+			getter: 'plasma_rootChain'
+		}),
+		new web3._extend.Property({
+			name: 'epoch',
+			getter: 'plasma_epoch'
+		}),
+		new web3._extend.Property({
+			name: 'currentFork',
+			getter: 'plasma_currentFork',
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Property({
+			name: 'lastFinalizedBlock',
+			getter: 'plasma_lastFinalizedBlock',
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Property({
+			name: 'lastFinalizedEpoch',
+			getter: 'plasma_lastFinalizedEpoch',
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Property({
+			name: 'pendingRequests',
+			getter: 'plasma_pendingRequests'
+		}),
+		new web3._extend.Property({
+			name: 'invalidExits',
+			getter: 'plasma_invalidExits'
+		}),
+		new web3._extend.Property({
+			name: 'failedRequests',
+			getter: 'plasma_failedRequests'
+		}),
+	]
+});
+`
+
+const StaminaJs = `
+web3._extend({
+	property: 'stamina',
+	methods: [
+		new web3._extend.Method({
+			name: 'getStamina',
+			call: 'stamina_getStamina',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+			outputFormatter: web3._extend.formatters.outputBigNumberFormatter
+		}),
+		new web3._extend.Method({
+			name: 'getDelegatee',
+			call: 'stamina_getDelegatee',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getDeposit',
+			call: 'stamina_getDeposit',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+			outputFormatter: web3._extend.formatters.outputBigNumberFormatter
+		}),
+		new web3._extend.Method({
+			name: 'getTotalDeposit',
+			call: 'stamina_getTotalDeposit',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+			outputFormatter: web3._extend.formatters.outputBigNumberFormatter
+		}),
+		new web3._extend.Method({
+			name: 'getRecovery',
+			call: 'stamina_getRecovery',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getWithdrawals',
+			call: 'stamina_getWithdrawals',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'config',
+			getter: 'stamina_config'
+		}),
+	]
+});
+`
+
+const StakingJs = `
+web3._extend({
+	property: 'staking',
+	methods: [
+		new web3._extend.Method({
+			name: 'getSeigniorage',
+			call: 'staking_seigniorage',
+			params: 3,
+			inputFormatter: [null, null, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
+		}),
+		new web3._extend.Method({
+			name: 'getWithdrawals',
+			call: 'staking_withdrawals',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getPowertonRounds',
+			call: 'staking_powertonRounds',
+			params: 2,
+			inputFormatter: [function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}, function(n) {
+				return n === undefined || n === null ? null : web3._extend.utils.fromDecimal(n);
+			}]
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'seigniorage',
+			getter: 'staking_seigniorage'
+		}),
+		new web3._extend.Property({
+			name: 'powertonRounds',
+			getter: 'staking_powertonRounds'
+		}),
+	]
+});
+`
+
+const StandbyJs = `
+web3._extend({
+	property: 'standby',
+	methods: [
+		new web3._extend.Method({
+			name: 'journal',
+			call: 'standby_journal',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'status',
+			getter: 'standby_status'
+		}),
+	]
+});
+`
+
+const TxManagerJs = `
+web3._extend({
+	property: 'txManager',
+	methods: [
+		new web3._extend.Method({
+			name: 'cancel',
+			call: 'txManager_cancel',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'replace',
+			call: 'txManager_replace',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal, null]
+		}),
+	]
+});
+`
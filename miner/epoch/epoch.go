@@ -3,6 +3,16 @@ package epoch
 import (
 	"math/big"
 	"sync"
+
+	"github.com/Onther-Tech/plasma-evm/metrics"
+)
+
+var (
+	epochNumberGauge        = metrics.NewRegisteredGauge("miner/epoch/number", nil)
+	epochForkGauge          = metrics.NewRegisteredGauge("miner/epoch/fork", nil)
+	epochLengthGauge        = metrics.NewRegisteredGauge("miner/epoch/length", nil)
+	epochMinedGauge         = metrics.NewRegisteredGauge("miner/epoch/mined", nil)
+	epochLastFinalizedGauge = metrics.NewRegisteredGauge("miner/epoch/lastfinalized", nil)
 )
 
 type EpochEnvironment struct {
@@ -63,6 +73,7 @@ func (self *EpochEnvironment) SetEpochNumber(e *big.Int) {
 	defer self.lock.Unlock()
 
 	self.EpochNumber = new(big.Int).Set(e)
+	epochNumberGauge.Update(e.Int64())
 }
 
 func (self *EpochEnvironment) SetIsRequest(b bool) {
@@ -98,6 +109,7 @@ func (self *EpochEnvironment) SetNumBlockMined(n *big.Int) {
 	defer self.lock.Unlock()
 
 	self.NumBlockMined = new(big.Int).Set(n)
+	epochMinedGauge.Update(n.Int64())
 }
 
 func (self *EpochEnvironment) SetEpochLength(l *big.Int) {
@@ -105,6 +117,7 @@ func (self *EpochEnvironment) SetEpochLength(l *big.Int) {
 	defer self.lock.Unlock()
 
 	self.EpochLength = new(big.Int).Set(l)
+	epochLengthGauge.Update(l.Int64())
 
 }
 
@@ -113,12 +126,14 @@ func (self *EpochEnvironment) SetCurrentFork(f *big.Int) {
 	defer self.lock.Unlock()
 
 	self.CurrentFork = new(big.Int).Set(f)
+	epochForkGauge.Update(f.Int64())
 }
 
 func (self *EpochEnvironment) SetLastFinalizedBlock(n *big.Int) {
 	self.lock.Lock()
 	defer self.lock.Unlock()
 	self.LastFinalizedBlock = new(big.Int).Set(n)
+	epochLastFinalizedGauge.Update(n.Int64())
 }
 
 func (self *EpochEnvironment) SetStartBlockNumber(n *big.Int) {
@@ -48,14 +48,15 @@ type Backend interface {
 
 // Config is the configuration parameters of mining.
 type Config struct {
-	Etherbase common.Address `toml:",omitempty"` // Public address for block mining rewards (default = first account)
-	Notify    []string       `toml:",omitempty"` // HTTP URL list to be notified of new work packages(only useful in ethash).
-	ExtraData hexutil.Bytes  `toml:",omitempty"` // Block extra data set by the miner
-	GasFloor  uint64         // Target gas floor for mined blocks.
-	GasCeil   uint64         // Target gas ceiling for mined blocks.
-	GasPrice  *big.Int       // Minimum gas price for mining a transaction
-	Recommit  time.Duration  // The time interval for miner to re-create mining work.
-	Noverify  bool           // Disable remote mining solution verification(only useful in ethash).
+	Etherbase        common.Address `toml:",omitempty"` // Public address for block mining rewards (default = first account)
+	Notify           []string       `toml:",omitempty"` // HTTP URL list to be notified of new work packages(only useful in ethash).
+	ExtraData        hexutil.Bytes  `toml:",omitempty"` // Block extra data set by the miner
+	GasFloor         uint64         // Target gas floor for mined blocks.
+	GasCeil          uint64         // Target gas ceiling for mined blocks.
+	GasPrice         *big.Int       // Minimum gas price for mining a transaction
+	Recommit         time.Duration  // The time interval for miner to re-create mining work.
+	MaxEpochDuration time.Duration  // Maximum NRE duration before empty NRBs are sealed to complete it (0 = disabled).
+	Noverify         bool           // Disable remote mining solution verification(only useful in ethash).
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -151,6 +152,7 @@ func (miner *Miner) Start(coinbase common.Address, e *rootchain.RootChainEpochPr
 		}
 
 		if !miner.env.Completed {
+			miner.worker.resumeEpoch()
 			miner.worker.start()
 			log.Info("current epoch is resumed")
 			return
@@ -179,6 +181,7 @@ func (miner *Miner) Start(coinbase common.Address, e *rootchain.RootChainEpochPr
 	} else {
 		log.Info("NRB epoch is prepared, NRB epoch is started", "epochLength", miner.env.EpochLength)
 	}
+	miner.worker.startEpoch()
 	miner.worker.start()
 }
 
@@ -188,6 +191,7 @@ func (miner *Miner) Stop() {
 }
 
 func (miner *Miner) Close() {
+	miner.worker.orbs.cancel("shutdown")
 	miner.worker.close()
 	close(miner.exitCh)
 }
@@ -247,3 +251,31 @@ func (miner *Miner) SetNRBepochLength(length *big.Int) {
 
 	miner.env.EpochLength = length
 }
+
+// CompleteEpoch makes the current NRE completed with empty blocks if there is
+// no pending transaction, so the epoch is submitted without waiting for users.
+func (miner *Miner) CompleteEpoch() {
+	miner.worker.completeEpoch()
+}
+
+// EnqueueORBs queues the bodies of the request blocks of a request epoch. The
+// bodies are mined one by one, starting from block number start.
+func (miner *Miner) EnqueueORBs(forkNumber, epochNumber, start *big.Int, bodies []types.Transactions) error {
+	return miner.worker.orbs.push(forkNumber, epochNumber, start, bodies)
+}
+
+// SubmittedORB marks the mined request block as submitted to the root chain.
+func (miner *Miner) SubmittedORB(number *big.Int) error {
+	return miner.worker.orbs.submitted(number)
+}
+
+// CancelORBs drops every request block which is not submitted yet.
+func (miner *Miner) CancelORBs(reason string) {
+	miner.worker.orbs.cancel(reason)
+}
+
+// ORBStates returns the production state of the request blocks of the current
+// request epoch keyed by block number.
+func (miner *Miner) ORBStates() map[uint64]ORBState {
+	return miner.worker.orbs.states()
+}
@@ -0,0 +1,200 @@
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/log"
+)
+
+// ORBState is the production state of a single request block.
+type ORBState uint8
+
+const (
+	ORBQueued    ORBState = iota // body is waiting for the previous ORB to be mined
+	ORBMining                    // body is enqueued in the tx pool and being mined
+	ORBMined                     // block is mined and waits for submission
+	ORBSubmitted                 // block submission is queued to the root chain
+)
+
+func (s ORBState) String() string {
+	switch s {
+	case ORBQueued:
+		return "queued"
+	case ORBMining:
+		return "mining"
+	case ORBMined:
+		return "mined"
+	case ORBSubmitted:
+		return "submitted"
+	}
+	return "unknown"
+}
+
+var (
+	errORBsPending    = errors.New("request blocks of previous epoch are not finished")
+	errNoORBs         = errors.New("no request block body")
+	errUnknownORB     = errors.New("unknown request block")
+	errORBNotMinedYet = errors.New("request block is not mined yet")
+)
+
+// orb is a request block tracked by orbQueue.
+type orb struct {
+	number *big.Int
+	body   types.Transactions
+	state  ORBState
+}
+
+// orbQueue drives request block production for a request epoch. Bodies are
+// handed to the tx pool one at a time; the next body is released only after
+// the previous request block is mined by the worker.
+type orbQueue struct {
+	enqueue func(types.Transactions) error // hands a body to the tx pool
+	reset   func()                         // drops request transactions from the tx pool
+
+	forkNumber  *big.Int
+	epochNumber *big.Int
+	orbs        []*orb
+	next        int // index of the ORB being mined
+
+	lock sync.Mutex
+}
+
+func newORBQueue(enqueue func(types.Transactions) error, reset func()) *orbQueue {
+	return &orbQueue{
+		enqueue: enqueue,
+		reset:   reset,
+	}
+}
+
+// push queues the bodies of a request epoch starting at block number start and
+// releases the first one to the tx pool.
+func (q *orbQueue) push(forkNumber, epochNumber, start *big.Int, bodies []types.Transactions) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(bodies) == 0 {
+		return errNoORBs
+	}
+	if q.pending() {
+		return errORBsPending
+	}
+
+	q.forkNumber = new(big.Int).Set(forkNumber)
+	q.epochNumber = new(big.Int).Set(epochNumber)
+	q.orbs = make([]*orb, 0, len(bodies))
+	q.next = 0
+
+	number := new(big.Int).Set(start)
+	for _, body := range bodies {
+		q.orbs = append(q.orbs, &orb{number: number, body: body, state: ORBQueued})
+		number = new(big.Int).Add(number, big.NewInt(1))
+	}
+	log.Info("Request blocks are queued", "fork", forkNumber, "epoch", epochNumber, "numORBs", len(bodies))
+
+	return q.release()
+}
+
+// release hands the next queued body to the tx pool. It must be called with
+// the lock held.
+func (q *orbQueue) release() error {
+	if q.next >= len(q.orbs) {
+		return nil
+	}
+	o := q.orbs[q.next]
+	if err := q.enqueue(o.body); err != nil {
+		return err
+	}
+	o.state = ORBMining
+	log.Debug("Request block body is released", "number", o.number, "txs", len(o.body))
+	return nil
+}
+
+// mined marks the ORB being mined as mined and releases the next body.
+func (q *orbQueue) mined(block *types.Block, receipts []*types.Receipt) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.next >= len(q.orbs) {
+		return errUnknownORB
+	}
+	o := q.orbs[q.next]
+	if o.number.Cmp(block.Number()) != 0 {
+		return errUnknownORB
+	}
+	for _, receipt := range receipts {
+		if receipt.Status == types.ReceiptStatusFailed {
+			log.Error("Request transaction is reverted", "blockNumber", block.Number(), "hash", receipt.TxHash)
+		}
+	}
+	o.state = ORBMined
+	q.next++
+
+	log.Info("New request block is mined", "blockNumber", block.Number(), "txs", block.Transactions().Len())
+
+	return q.release()
+}
+
+// submitted marks a mined ORB as submitted. The queue is cleared once every
+// ORB of the epoch is submitted.
+func (q *orbQueue) submitted(number *big.Int) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, o := range q.orbs {
+		if o.number.Cmp(number) != 0 {
+			continue
+		}
+		if o.state != ORBMined && o.state != ORBSubmitted {
+			return errORBNotMinedYet
+		}
+		o.state = ORBSubmitted
+		if !q.pending() {
+			log.Info("Request epoch is submitted", "fork", q.forkNumber, "epoch", q.epochNumber)
+			q.orbs = nil
+		}
+		return nil
+	}
+	return errUnknownORB
+}
+
+// cancel drops every unfinished ORB and the request transactions already
+// handed to the tx pool.
+func (q *orbQueue) cancel(reason string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !q.pending() {
+		return
+	}
+	log.Warn("Request blocks are cancelled", "fork", q.forkNumber, "epoch", q.epochNumber, "mined", q.next, "numORBs", len(q.orbs), "reason", reason)
+
+	q.orbs = nil
+	q.next = 0
+	q.reset()
+}
+
+// pending reports whether some ORB is not submitted yet. It must be called
+// with the lock held.
+func (q *orbQueue) pending() bool {
+	for _, o := range q.orbs {
+		if o.state != ORBSubmitted {
+			return true
+		}
+	}
+	return false
+}
+
+// states returns the state of each tracked ORB keyed by block number.
+func (q *orbQueue) states() map[uint64]ORBState {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	states := make(map[uint64]ORBState, len(q.orbs))
+	for _, o := range q.orbs {
+		states[o.number.Uint64()] = o.state
+	}
+	return states
+}
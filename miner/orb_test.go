@@ -0,0 +1,84 @@
+package miner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/core/types"
+)
+
+// Tests that request block bodies are released one by one as the previous
+// request block is mined, and that the queue is cleared once every request
+// block is submitted.
+func TestORBQueueLifecycle(t *testing.T) {
+	var released []types.Transactions
+	q := newORBQueue(func(body types.Transactions) error {
+		released = append(released, body)
+		return nil
+	}, func() {})
+
+	bodies := []types.Transactions{
+		{types.NewTransaction(0, [20]byte{1}, big.NewInt(1), 21000, big.NewInt(0), nil)},
+		{types.NewTransaction(0, [20]byte{2}, big.NewInt(2), 21000, big.NewInt(0), nil)},
+	}
+	if err := q.push(big.NewInt(0), big.NewInt(2), big.NewInt(5), bodies); err != nil {
+		t.Fatalf("failed to push bodies: %v", err)
+	}
+	if len(released) != 1 {
+		t.Fatalf("released bodies mismatch: have %d, want %d", len(released), 1)
+	}
+	if state := q.states()[5]; state != ORBMining {
+		t.Fatalf("ORB#5 state mismatch: have %v, want %v", state, ORBMining)
+	}
+	if err := q.push(big.NewInt(0), big.NewInt(4), big.NewInt(7), bodies); err != errORBsPending {
+		t.Fatalf("push error mismatch: have %v, want %v", err, errORBsPending)
+	}
+	if err := q.submitted(big.NewInt(6)); err != errORBNotMinedYet {
+		t.Fatalf("submit error mismatch: have %v, want %v", err, errORBNotMinedYet)
+	}
+
+	for i, number := range []int64{5, 6} {
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number)})
+		if err := q.mined(block, nil); err != nil {
+			t.Fatalf("failed to mark ORB#%d mined: %v", number, err)
+		}
+		if len(released) != 2 {
+			t.Fatalf("released bodies mismatch after ORB#%d: have %d, want %d", number, len(released), 2)
+		}
+		if state := q.states()[uint64(number)]; state != ORBMined {
+			t.Fatalf("ORB#%d state mismatch: have %v, want %v", number, state, ORBMined)
+		}
+		if err := q.submitted(big.NewInt(number)); err != nil {
+			t.Fatalf("failed to mark ORB#%d submitted: %v", number, err)
+		}
+		if i == 0 {
+			if state := q.states()[uint64(number)]; state != ORBSubmitted {
+				t.Fatalf("ORB#%d state mismatch: have %v, want %v", number, state, ORBSubmitted)
+			}
+		}
+	}
+	if states := q.states(); len(states) != 0 {
+		t.Fatalf("queue is not cleared: %v", states)
+	}
+}
+
+// Tests that cancelling drops unfinished request blocks and resets the tx pool.
+func TestORBQueueCancel(t *testing.T) {
+	reset := false
+	q := newORBQueue(func(types.Transactions) error { return nil }, func() { reset = true })
+
+	if err := q.push(big.NewInt(0), big.NewInt(2), big.NewInt(5), []types.Transactions{{}, {}}); err != nil {
+		t.Fatalf("failed to push bodies: %v", err)
+	}
+	q.cancel("test")
+
+	if !reset {
+		t.Fatalf("request transactions are not reset")
+	}
+	if states := q.states(); len(states) != 0 {
+		t.Fatalf("queue is not cleared: %v", states)
+	}
+	if err := q.push(big.NewInt(1), big.NewInt(3), big.NewInt(5), []types.Transactions{{}}); err != nil {
+		t.Fatalf("failed to push bodies after cancel: %v", err)
+	}
+}
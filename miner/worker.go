@@ -34,6 +34,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/ethdb"
 	"github.com/Onther-Tech/plasma-evm/event"
 	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/metrics"
 	"github.com/Onther-Tech/plasma-evm/miner/epoch"
 	"github.com/Onther-Tech/plasma-evm/params"
 	mapset "github.com/deckarep/golang-set"
@@ -79,6 +80,12 @@ const (
 	staleThreshold = 7
 )
 
+var (
+	emptyNRBMeter      = metrics.NewRegisteredMeter("miner/nrb/empty", nil)
+	epochExpiredMeter  = metrics.NewRegisteredMeter("miner/epoch/expired", nil)
+	epochDurationTimer = metrics.NewRegisteredTimer("miner/epoch/duration", nil)
+)
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
 	signer types.Signer
@@ -93,6 +100,8 @@ type environment struct {
 	header   *types.Header
 	txs      []*types.Transaction
 	receipts []*types.Receipt
+
+	empty bool // whether the block is intentionally sealed without transactions
 }
 
 // task contains all information for consensus engine sealing and result submitting.
@@ -101,6 +110,7 @@ type task struct {
 	state     *state.StateDB
 	block     *types.Block
 	createdAt time.Time
+	empty     bool
 }
 
 const (
@@ -166,13 +176,17 @@ type worker struct {
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
+	orbs *orbQueue // Request block bodies waiting to be mined and submitted.
+
 	snapshotMu    sync.RWMutex // The lock used to protect the block snapshot and state snapshot
 	snapshotBlock *types.Block
 	snapshotState *state.StateDB
 
 	// atomic status counters
-	running int32 // The indicator whether the consensus engine is running or not.
-	newTxs  int32 // New arrival transaction count since last sealing work submitting.
+	running      int32 // The indicator whether the consensus engine is running or not.
+	newTxs       int32 // New arrival transaction count since last sealing work submitting.
+	epochStarted int64 // Unix nano timestamp at which the current epoch was started.
+	completing   int32 // The indicator whether the current epoch is requested to be completed with empty blocks.
 
 	// External functions
 	isLocalBlock func(block *types.Block) bool // Function used to determine whether the specified block is mined by local miner.
@@ -201,6 +215,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		remoteUncles:       make(map[common.Hash]*types.Block),
 		unconfirmed:        newUnconfirmedBlocks(pls.BlockChain(), miningLogAtDepth),
 		pendingTasks:       make(map[common.Hash]*task),
+		orbs:               newORBQueue(pls.TxPool().EnqueueReqeustTxs, func() { pls.TxPool().RemovePendingRequests() }),
 		txsCh:              make(chan core.NewTxsEvent, txChanSize),
 		chainHeadCh:        make(chan core.ChainHeadEvent, chainHeadChanSize),
 		chainSideCh:        make(chan core.ChainSideEvent, chainSideChanSize),
@@ -281,6 +296,26 @@ func (w *worker) start() {
 	w.startCh <- struct{}{}
 }
 
+// startEpoch marks the current epoch as started now. It is called when a new
+// epoch is prepared, not when the worker is started again in the same epoch.
+func (w *worker) startEpoch() {
+	atomic.StoreInt64(&w.epochStarted, time.Now().UnixNano())
+	atomic.StoreInt32(&w.completing, 0)
+}
+
+// resumeEpoch restores the start time of the current epoch from the timestamp
+// of its first block. If no block of the epoch is mined yet, the known start
+// time is kept, or the epoch is marked as started now.
+func (w *worker) resumeEpoch() {
+	if w.env.NumBlockMined.Sign() > 0 {
+		if header := w.chain.GetHeaderByNumber(w.env.StartBlockNumber.Uint64()); header != nil {
+			atomic.StoreInt64(&w.epochStarted, time.Unix(int64(header.Time), 0).UnixNano())
+			return
+		}
+	}
+	atomic.CompareAndSwapInt64(&w.epochStarted, 0, time.Now().UnixNano())
+}
+
 // stop sets the running status as 0.
 func (w *worker) stop() {
 	atomic.StoreInt32(&w.running, 0)
@@ -291,6 +326,27 @@ func (w *worker) isRunning() bool {
 	return atomic.LoadInt32(&w.running) == 1
 }
 
+// completeEpoch requests the current NRE to be completed with empty blocks.
+func (w *worker) completeEpoch() {
+	atomic.StoreInt32(&w.completing, 1)
+}
+
+// epochExpired returns an indicator whether the current epoch has lasted longer
+// than the configured maximum epoch duration, or is requested to be completed.
+func (w *worker) epochExpired() bool {
+	if atomic.LoadInt32(&w.completing) == 1 {
+		return true
+	}
+	if w.config.MaxEpochDuration <= 0 {
+		return false
+	}
+	started := atomic.LoadInt64(&w.epochStarted)
+	if started == 0 {
+		return false
+	}
+	return time.Since(time.Unix(0, started)) >= w.config.MaxEpochDuration
+}
+
 // close terminates all background threads maintained by the worker.
 // Note the worker does not support being closed multiple times.
 func (w *worker) close() {
@@ -303,6 +359,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		interrupt   *int32
 		minRecommit = recommit // minimal resubmit interval specified by user.
 		timestamp   int64      // timestamp for each round of mining.
+		expired     bool       // whether the current epoch is being filled with empty blocks.
 	)
 
 	timer := time.NewTimer(0)
@@ -357,6 +414,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 
 		// clear pending task when miner is started
 		case <-w.startCh:
+			expired = false
 			clearPending(w.chain.CurrentBlock().NumberU64())
 			timer.Reset(recommit)
 			timestamp = time.Now().Unix()
@@ -381,9 +439,21 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 						continue
 					}
 				} else {
-					// Short circuit if there is no pending transaction.
+					// Short circuit if there is no pending transaction, unless the
+					// epoch has expired and must be completed with empty blocks.
 					pending, _ := w.pls.TxPool().Pending()
 					if len(pending) == 0 {
+						if !w.epochExpired() {
+							continue
+						}
+						if !expired {
+							expired = true
+							epochExpiredMeter.Mark(1)
+							log.Info("NRB epoch expired, sealing empty blocks", "epoch", w.env.EpochNumber,
+								"mined", w.env.NumBlockMined, "length", w.env.EpochLength)
+						}
+						timestamp = time.Now().Unix()
+						commit(false, commitInterruptResubmit)
 						continue
 					}
 				}
@@ -555,7 +625,7 @@ func (w *worker) taskLoop() {
 			w.pendingTasks[w.engine.SealHash(task.block.Header())] = task
 			w.pendingMu.Unlock()
 
-			if err := w.engine.Seal(w.chain, task.block, w.resultCh, stopCh); err != nil {
+			if err := w.seal(task, stopCh); err != nil {
 				log.Warn("Block sealing failed", "err", err)
 			}
 		case <-w.exitCh:
@@ -565,6 +635,15 @@ func (w *worker) taskLoop() {
 	}
 }
 
+// seal pushes the task to the consensus engine. The empty blocks completing an
+// epoch are sealed explicitly, as some engines refuse to seal empty blocks.
+func (w *worker) seal(task *task, stop <-chan struct{}) error {
+	if sealer, ok := w.engine.(consensus.EmptySealer); ok && task.empty {
+		return sealer.SealEmpty(w.chain, task.block, w.resultCh, stop)
+	}
+	return w.engine.Seal(w.chain, task.block, w.resultCh, stop)
+}
+
 // resultLoop is a standalone goroutine to handle sealing result submitting
 // and flush relative data to the database.
 func (w *worker) resultLoop() {
@@ -583,11 +662,6 @@ func (w *worker) resultLoop() {
 			if block.Header().Number.Uint64() <= w.chain.CurrentBlock().NumberU64() {
 				continue
 			}
-			// Short circuit if block has no transaction
-			if block.Transactions().Len() == 0 {
-				continue
-			}
-
 			var (
 				sealhash = w.engine.SealHash(block.Header())
 				hash     = block.Hash()
@@ -599,6 +673,10 @@ func (w *worker) resultLoop() {
 				log.Error("Block found but no relative pending task", "number", block.Number(), "sealhash", sealhash, "hash", hash)
 				continue
 			}
+			// Short circuit if block has no transaction and is not meant to be empty
+			if block.Transactions().Len() == 0 && !task.empty {
+				continue
+			}
 			// Different block could share same sealhash, deep copy here to prevent write-write conflict.
 			var (
 				receipts = make([]*types.Receipt, len(task.receipts))
@@ -628,12 +706,20 @@ func (w *worker) resultLoop() {
 			log.Info("Successfully sealed new block", "number", block.Number(), "sealhash", sealhash, "hash", hash,
 				"elapsed", common.PrettyDuration(time.Since(task.createdAt)))
 
+			if task.empty {
+				emptyNRBMeter.Mark(1)
+			}
+
 			// add 1 to number of block mined
 			w.env.SetNumBlockMined(new(big.Int).Add(w.env.NumBlockMined, big.NewInt(1)))
 
 			// check if the epoch is completed
 			if w.env.NumBlockMined.Cmp(w.env.EpochLength) == 0 {
 				w.env.SetCompleted(true)
+				atomic.StoreInt32(&w.completing, 0)
+				if started := atomic.LoadInt64(&w.epochStarted); started != 0 {
+					epochDurationTimer.UpdateSince(time.Unix(0, started))
+				}
 			}
 
 			w.env.Lock()
@@ -643,6 +729,13 @@ func (w *worker) resultLoop() {
 			// clear pending request transactions
 			w.pls.TxPool().RemovePendingRequests()
 
+			// release the next request block body
+			if block.IsRequest() {
+				if err := w.orbs.mined(block, receipts); err != nil {
+					log.Error("Failed to handle mined request block", "number", block.Number(), "err", err)
+				}
+			}
+
 			// Broadcast the block and announce chain insertion event
 			w.mux.Post(core.NewMinedBlockEvent{Block: block})
 
@@ -1018,9 +1111,15 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		log.Error("Failed to fetch pending transactions", "err", err)
 		return
 	}
-	// Short circuit if there is no available pending transactions
+	// Short circuit if there is no available pending transactions, unless an
+	// empty block is requested to complete an expired epoch.
 	if len(pending) == 0 {
-		w.updateSnapshot()
+		if noempty {
+			w.updateSnapshot()
+			return
+		}
+		w.current.empty = true
+		w.commit(uncles, w.fullTaskHook, true, tstart)
 		return
 	}
 	// Split the pending transactions into locals and remotes
@@ -1147,7 +1246,7 @@ func (w *worker) commit(uncles []*types.Header, interval func(), update bool, st
 			interval()
 		}
 		select {
-		case w.taskCh <- &task{receipts: receipts, state: s, block: block, createdAt: time.Now()}:
+		case w.taskCh <- &task{receipts: receipts, state: s, block: block, createdAt: time.Now(), empty: w.current.empty}:
 			w.unconfirmed.Shift(block.NumberU64() - 1)
 
 			feesWei := new(big.Int)
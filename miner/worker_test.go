@@ -208,7 +208,7 @@ func testGenerateBlockAndImport(t *testing.T, isClique bool) {
 		engine = ethash.NewFaker()
 	}
 
-	w, b := newTestWorker(t, chainConfig, engine, db, 0)
+	w, b := newTestWorker(t, chainConfig, engine, epoch.New(), db, 0)
 	defer w.close()
 
 	db2 := rawdb.NewMemoryDatabase()
@@ -218,8 +218,9 @@ func testGenerateBlockAndImport(t *testing.T, isClique bool) {
 
 	loopErr := make(chan error)
 	newBlock := make(chan struct{})
+	// subscribe before mining, the first work has the pending tx to be sealed
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
 	listenNewBlock := func() {
-		sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
 		defer sub.Unsubscribe()
 
 		for item := range sub.Chan() {
@@ -263,32 +264,17 @@ func TestEmptyWorkClique(t *testing.T) {
 func testEmptyWork(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine) {
 	defer engine.Close()
 
-	w, _ := newTestWorker(t, chainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	w, _ := newTestWorker(t, chainConfig, engine, epoch.New(), rawdb.NewMemoryDatabase(), 0)
 	defer w.close()
 
-	var (
-		taskIndex int
-		taskCh    = make(chan struct{}, 2)
-	)
-	checkEqual := func(t *testing.T, task *task, index int) {
-		// The first empty work without any txs included
-		receiptLen, balance := 0, big.NewInt(0)
-		if index == 1 {
-			// The second full work with 1 tx included
-			receiptLen, balance = 1, big.NewInt(1000)
-		}
-		if len(task.receipts) != receiptLen {
-			t.Fatalf("receipt number mismatch: have %d, want %d", len(task.receipts), receiptLen)
-		}
-		if task.state.GetBalance(testUserAddress).Cmp(balance) != 0 {
-			t.Fatalf("account balance mismatch: have %d, want %d", task.state.GetBalance(testUserAddress), balance)
-		}
-	}
+	// plasma does not pre-seal an empty block, the first work has the pending tx
+	taskCh := make(chan *task, 1)
 	w.newTaskHook = func(task *task) {
 		if task.block.NumberU64() == 1 {
-			checkEqual(t, task, taskIndex)
-			taskIndex += 1
-			taskCh <- struct{}{}
+			select {
+			case taskCh <- task:
+			default:
+			}
 		}
 	}
 	w.skipSealHook = func(task *task) bool { return true }
@@ -298,12 +284,17 @@ func testEmptyWork(t *testing.T, chainConfig *params.ChainConfig, engine consens
 		time.Sleep(time.Second)
 	}
 	w.start() // Start mining!
-	for i := 0; i < 2; i += 1 {
-		select {
-		case <-taskCh:
-		case <-time.NewTimer(3 * time.Second).C:
-			t.Error("new task timeout")
+
+	select {
+	case task := <-taskCh:
+		if len(task.receipts) != 1 {
+			t.Fatalf("receipt number mismatch: have %d, want %d", len(task.receipts), 1)
+		}
+		if balance := task.state.GetBalance(testUserAddress); balance.Cmp(big.NewInt(1000)) != 0 {
+			t.Fatalf("account balance mismatch: have %d, want %d", balance, 1000)
 		}
+	case <-time.NewTimer(3 * time.Second).C:
+		t.Error("new task timeout")
 	}
 }
 
@@ -311,26 +302,17 @@ func TestStreamUncleBlock(t *testing.T) {
 	ethash := ethash.NewFaker()
 	defer ethash.Close()
 
-	w, b := newTestWorker(t, ethashChainConfig, ethash, *epoch.EpochEnvironment, rawdb.NewMemoryDatabase(), 1)
+	w, b := newTestWorker(t, ethashChainConfig, ethash, epoch.New(), rawdb.NewMemoryDatabase(), 1)
 	defer w.close()
 
-	var taskCh = make(chan struct{})
+	var taskCh = make(chan *task, 1)
 
-	taskIndex := 0
 	w.newTaskHook = func(task *task) {
 		if task.block.NumberU64() == 2 {
-			// The first task is an empty task, the second
-			// one has 1 pending tx, the third one has 1 tx
-			// and 1 uncle.
-			if taskIndex == 2 {
-				have := task.block.Header().UncleHash
-				want := types.CalcUncleHash([]*types.Header{b.uncleBlock.Header()})
-				if have != want {
-					t.Errorf("uncle hash mismatch: have %s, want %s", have.Hex(), want.Hex())
-				}
+			select {
+			case taskCh <- task:
+			default:
 			}
-			taskCh <- struct{}{}
-			taskIndex += 1
 		}
 	}
 	w.skipSealHook = func(task *task) bool {
@@ -341,19 +323,22 @@ func TestStreamUncleBlock(t *testing.T) {
 	}
 	w.start()
 
-	for i := 0; i < 2; i += 1 {
-		select {
-		case <-taskCh:
-		case <-time.NewTimer(time.Second).C:
-			t.Error("new task timeout")
-		}
+	select {
+	case <-taskCh:
+	case <-time.NewTimer(time.Second).C:
+		t.Error("new task timeout")
 	}
 
+	// plasma blocks have no uncle, side blocks are ignored
 	w.postSideBlock(core.ChainSideEvent{Block: b.uncleBlock})
+	b.txPool.AddLocals(newTxs)
 
 	select {
-	case <-taskCh:
-	case <-time.NewTimer(time.Second).C:
+	case task := <-taskCh:
+		if have := task.block.Header().UncleHash; have != types.EmptyUncleHash {
+			t.Errorf("uncle hash mismatch: have %s, want %s", have.Hex(), types.EmptyUncleHash.Hex())
+		}
+	case <-time.NewTimer(2 * time.Second).C:
 		t.Error("new task timeout")
 	}
 }
@@ -369,27 +354,17 @@ func TestRegenerateMiningBlockClique(t *testing.T) {
 func testRegenerateMiningBlock(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine) {
 	defer engine.Close()
 
-	w, b := newTestWorker(t, chainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	w, b := newTestWorker(t, chainConfig, engine, epoch.New(), rawdb.NewMemoryDatabase(), 0)
 	defer w.close()
 
-	var taskCh = make(chan struct{})
+	var taskCh = make(chan *task, 1)
 
-	taskIndex := 0
 	w.newTaskHook = func(task *task) {
 		if task.block.NumberU64() == 1 {
-			// The first task is an empty task, the second
-			// one has 1 pending tx, the third one has 2 txs
-			if taskIndex == 2 {
-				receiptLen, balance := 2, big.NewInt(2000)
-				if len(task.receipts) != receiptLen {
-					t.Errorf("receipt number mismatch: have %d, want %d", len(task.receipts), receiptLen)
-				}
-				if task.state.GetBalance(testUserAddress).Cmp(balance) != 0 {
-					t.Errorf("account balance mismatch: have %d, want %d", task.state.GetBalance(testUserAddress), balance)
-				}
+			select {
+			case taskCh <- task:
+			default:
 			}
-			taskCh <- struct{}{}
-			taskIndex += 1
 		}
 	}
 	w.skipSealHook = func(task *task) bool {
@@ -400,21 +375,32 @@ func testRegenerateMiningBlock(t *testing.T, chainConfig *params.ChainConfig, en
 	}
 
 	w.start()
-	// Ignore the first two works
-	for i := 0; i < 2; i += 1 {
-		select {
-		case <-taskCh:
-		case <-time.NewTimer(time.Second).C:
-			t.Error("new task timeout")
+	// The first work has 1 pending tx
+	select {
+	case task := <-taskCh:
+		if len(task.receipts) != 1 {
+			t.Errorf("receipt number mismatch: have %d, want %d", len(task.receipts), 1)
 		}
+	case <-time.NewTimer(time.Second).C:
+		t.Error("new task timeout")
 	}
 	b.txPool.AddLocals(newTxs)
 	time.Sleep(time.Second)
 
-	select {
-	case <-taskCh:
-	case <-time.NewTimer(time.Second).C:
-		t.Error("new task timeout")
+	// The work regenerated on recommit has 2 txs
+	for {
+		select {
+		case task := <-taskCh:
+			if len(task.receipts) < 2 {
+				continue
+			}
+			if balance := task.state.GetBalance(testUserAddress); balance.Cmp(big.NewInt(2000)) != 0 {
+				t.Errorf("account balance mismatch: have %d, want %d", balance, 2000)
+			}
+		case <-time.NewTimer(2 * time.Second).C:
+			t.Error("new task timeout")
+		}
+		return
 	}
 }
 
@@ -429,7 +415,7 @@ func TestAdjustIntervalClique(t *testing.T) {
 func testAdjustInterval(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine) {
 	defer engine.Close()
 
-	w, _ := newTestWorker(t, chainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	w, _ := newTestWorker(t, chainConfig, engine, epoch.New(), rawdb.NewMemoryDatabase(), 0)
 	defer w.close()
 
 	w.skipSealHook = func(task *task) bool {
@@ -511,3 +497,73 @@ func testAdjustInterval(t *testing.T, chainConfig *params.ChainConfig, engine co
 		t.Error("interval reset timeout")
 	}
 }
+
+func TestSealEmptyNRBCliquePeriod0(t *testing.T) {
+	var (
+		db          = rawdb.NewMemoryDatabase()
+		chainConfig = *params.AllCliqueProtocolChanges
+	)
+	// 0-period clique refuses to seal empty blocks unless they are requested
+	chainConfig.Clique = &params.CliqueConfig{Period: 0, Epoch: 30000}
+	engine := clique.New(chainConfig.Clique, db)
+	defer engine.Close()
+
+	env := epoch.New()
+	env.SetStartBlockNumber(big.NewInt(1))
+	env.SetEndBlockNumber(big.NewInt(1))
+	env.SetEpochLength(big.NewInt(1))
+
+	config := *testConfig
+	config.MaxEpochDuration = time.Millisecond
+
+	backend := newTestWorkerBackend(t, &chainConfig, engine, db, 0)
+	w := newWorker(&config, &chainConfig, engine, backend, env, new(event.TypeMux), db, nil, false)
+	w.setEtherbase(testBankAddress)
+	defer w.close()
+
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	w.startEpoch()
+	w.start()
+
+	select {
+	case ev := <-sub.Chan():
+		block := ev.Data.(core.NewMinedBlockEvent).Block
+		if block.NumberU64() != 1 || len(block.Transactions()) != 0 {
+			t.Fatalf("mined block mismatch: number %d, txs %d", block.NumberU64(), len(block.Transactions()))
+		}
+	case <-time.NewTimer(5 * time.Second).C:
+		t.Fatal("empty NRB is not sealed")
+	}
+	if !env.Completed {
+		t.Error("epoch is not completed by the empty NRB")
+	}
+}
+
+func TestResumeEpoch(t *testing.T) {
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	env := epoch.New()
+	w, b := newTestWorker(t, ethashChainConfig, engine, env, rawdb.NewMemoryDatabase(), 2)
+	defer w.close()
+
+	// the epoch start is kept until the first block of the epoch is mined
+	w.startEpoch()
+	started := atomic.LoadInt64(&w.epochStarted)
+	env.SetStartBlockNumber(big.NewInt(1))
+	w.resumeEpoch()
+	if have := atomic.LoadInt64(&w.epochStarted); have != started {
+		t.Fatalf("epoch start mismatch before the first block: have %d, want %d", have, started)
+	}
+
+	// the epoch start is restored from the first block of the epoch
+	env.SetNumBlockMined(big.NewInt(2))
+	atomic.StoreInt64(&w.epochStarted, 0)
+	w.resumeEpoch()
+	want := time.Unix(int64(b.chain.GetHeaderByNumber(1).Time), 0).UnixNano()
+	if have := atomic.LoadInt64(&w.epochStarted); have != want {
+		t.Fatalf("epoch start mismatch after the first block: have %d, want %d", have, want)
+	}
+}
@@ -19,6 +19,8 @@ var (
 	RequestTxGasPrice        = big.NewInt(1e9)
 	RequestTxGasLimit uint64 = 100000
 
+	EndRoundGasLimit uint64 = 1000000
+
 	TONDecimals  = 18
 	WTONDecimals = 27
 )
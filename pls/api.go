@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"os"
 	"runtime"
@@ -38,6 +39,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/rlp"
 	"github.com/Onther-Tech/plasma-evm/rpc"
 	"github.com/Onther-Tech/plasma-evm/trie"
+	"github.com/Onther-Tech/plasma-evm/tx"
 )
 
 // PublicPlasmaAPI provides an API to access Ethereum full node-related
@@ -264,6 +266,164 @@ func (api *PrivateAdminAPI) ImportChain(file string) (bool, error) {
 	return true, nil
 }
 
+// RootChainProviders returns the last health check results of the root chain
+// providers in the order of preference, and which one is in use.
+func (api *PrivateAdminAPI) RootChainProviders() []map[string]interface{} {
+	rcm := api.pls.rootchainManager
+	current := rcm.BackendStatus().URL
+
+	providers := make([]map[string]interface{}, 0)
+	for _, status := range rcm.BackendStatuses() {
+		provider := map[string]interface{}{
+			"url":     status.URL,
+			"current": status.URL == current,
+			"alive":   status.Alive,
+			"latency": status.Latency.String(),
+		}
+		if !status.CheckedAt.IsZero() {
+			provider["checkedAt"] = hexutil.Uint64(status.CheckedAt.Unix())
+		}
+		if status.Err != nil {
+			provider["error"] = status.Err.Error()
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// RootChainNonces returns the last nonce reconciliation results of the
+// operator accounts with the root chain.
+func (api *PrivateAdminAPI) RootChainNonces() []map[string]interface{} {
+	return formatNonceReports(api.pls.rootchainManager.txManager.NonceReports())
+}
+
+// ReconcileRootChainNonces reconciles the nonces of the operator accounts with
+// the root chain and repairs the drifted ones immediately.
+func (api *PrivateAdminAPI) ReconcileRootChainNonces() []map[string]interface{} {
+	return formatNonceReports(api.pls.rootchainManager.txManager.ReconcileNonces())
+}
+
+// costRange returns the unix time range of the dates in "2006-01-02", both
+// inclusive. All the entries are in range if the dates are not specified.
+func costRange(from, to *string) (uint64, uint64, error) {
+	start, end := uint64(0), uint64(math.MaxUint64)
+	if from != nil {
+		t, err := time.Parse(costDateLayout, *from)
+		if err != nil {
+			return 0, 0, err
+		}
+		start = uint64(t.Unix())
+	}
+	if to != nil {
+		t, err := time.Parse(costDateLayout, *to)
+		if err != nil {
+			return 0, 0, err
+		}
+		end = uint64(t.AddDate(0, 0, 1).Unix())
+	}
+	return start, end, nil
+}
+
+// RootChainCosts returns the costs of the root chain transactions confirmed
+// in the dates.
+func (api *PrivateAdminAPI) RootChainCosts(from, to *string) ([]map[string]interface{}, error) {
+	start, end, err := costRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make([]map[string]interface{}, 0)
+	for _, e := range api.pls.rootchainManager.costs.entries(start, end) {
+		costs = append(costs, map[string]interface{}{
+			"from":        e.From,
+			"caption":     e.Caption,
+			"epoch":       hexutil.Uint64(e.Epoch),
+			"startBlock":  hexutil.Uint64(e.StartBlock),
+			"endBlock":    hexutil.Uint64(e.EndBlock),
+			"txHash":      e.TxHash,
+			"blockNumber": hexutil.Uint64(e.BlockNumber),
+			"time":        hexutil.Uint64(e.Time),
+			"gasUsed":     hexutil.Uint64(e.GasUsed),
+			"gasPrice":    (*hexutil.Big)(e.GasPrice),
+			"fee":         (*hexutil.Big)(e.Fee()),
+			"value":       (*hexutil.Big)(e.Value),
+			"spent":       (*hexutil.Big)(e.Spent()),
+			"recovered":   (*hexutil.Big)(e.Recovered),
+			"reverted":    e.Reverted,
+		})
+	}
+	return costs, nil
+}
+
+// RootChainDailyCosts returns the costs of the root chain transactions summed
+// by day in UTC.
+func (api *PrivateAdminAPI) RootChainDailyCosts(from, to *string) ([]map[string]interface{}, error) {
+	start, end, err := costRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]map[string]interface{}, 0)
+	for _, day := range dailyCosts(api.pls.rootchainManager.costs.entries(start, end)) {
+		days = append(days, map[string]interface{}{
+			"date":      day.Date,
+			"count":     hexutil.Uint64(day.Count),
+			"reverted":  hexutil.Uint64(day.Reverted),
+			"gasUsed":   hexutil.Uint64(day.GasUsed),
+			"fee":       (*hexutil.Big)(day.Fee),
+			"spent":     (*hexutil.Big)(day.Spent),
+			"recovered": (*hexutil.Big)(day.Recovered),
+			"net":       (*hexutil.Big)(day.Net()),
+		})
+	}
+	return days, nil
+}
+
+// ExportRootChainCosts exports the costs of the root chain transactions
+// confirmed in the dates into a CSV file.
+func (api *PrivateAdminAPI) ExportRootChainCosts(file string, from, to *string) (bool, error) {
+	start, end, err := costRange(from, to)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(file); err == nil {
+		return false, errors.New("location would overwrite an existing file")
+	}
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return false, err
+	}
+	defer out.Close()
+
+	if err := writeCostsCSV(out, api.pls.rootchainManager.costs.entries(start, end)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func formatNonceReports(reports []*tx.NonceReport) []map[string]interface{} {
+	formatted := make([]map[string]interface{}, 0, len(reports))
+	for _, report := range reports {
+		fields := map[string]interface{}{
+			"address":    report.Address,
+			"local":      hexutil.Uint64(report.Local),
+			"next":       hexutil.Uint64(report.Next),
+			"mined":      hexutil.Uint64(report.Mined),
+			"pending":    hexutil.Uint64(report.Pending),
+			"drifted":    report.Drifted(),
+			"conflicts":  report.Conflicts,
+			"renumbered": report.Renumbered,
+			"gaps":       report.Gaps,
+			"checkedAt":  hexutil.Uint64(report.CheckedAt.Unix()),
+		}
+		if report.Err != nil {
+			fields["error"] = report.Err.Error()
+		}
+		formatted = append(formatted, fields)
+	}
+	return formatted
+}
+
 // PublicDebugAPI is the collection of Ethereum full node APIs exposed
 // over the public debugging endpoint.
 type PublicDebugAPI struct {
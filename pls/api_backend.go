@@ -24,6 +24,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/accounts"
 	"github.com/Onther-Tech/plasma-evm/common"
 	"github.com/Onther-Tech/plasma-evm/common/math"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
 	"github.com/Onther-Tech/plasma-evm/core"
 	"github.com/Onther-Tech/plasma-evm/core/bloombits"
 	"github.com/Onther-Tech/plasma-evm/core/rawdb"
@@ -283,6 +284,15 @@ func (b *PlsAPIBackend) ChainDb() ethdb.Database {
 	return b.pls.ChainDb()
 }
 
+// RootChainContract returns RootChain contract binding, or nil if the root
+// chain manager is not running.
+func (b *PlsAPIBackend) RootChainContract() *rootchain.RootChain {
+	if b.pls.rootchainManager == nil {
+		return nil
+	}
+	return b.pls.rootchainManager.rootchainContract
+}
+
 func (b *PlsAPIBackend) EventMux() *event.TypeMux {
 	return b.pls.EventMux()
 }
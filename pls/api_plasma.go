@@ -0,0 +1,341 @@
+package pls
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+)
+
+// maxPendingRequests is the maximum number of requests returned by PendingRequests.
+const maxPendingRequests = 256
+
+// PublicRootChainAPI provides an API to inspect epochs, blocks and requests
+// of the plasma chain recorded in RootChain contract.
+type PublicRootChainAPI struct {
+	p *Plasma
+}
+
+// NewPublicRootChainAPI creates a new plasma API.
+func NewPublicRootChainAPI(p *Plasma) *PublicRootChainAPI {
+	return &PublicRootChainAPI{p}
+}
+
+// RootChain returns the address of RootChain contract.
+func (api *PublicRootChainAPI) RootChain() common.Address {
+	return api.p.config.RootChainContract
+}
+
+// Epoch returns the epoch the miner is working on.
+func (api *PublicRootChainAPI) Epoch() map[string]interface{} {
+	env := api.p.rootchainManager.minerEnv
+
+	env.Lock()
+	defer env.Unlock()
+
+	return map[string]interface{}{
+		"forkNumber":       (*hexutil.Big)(env.CurrentFork),
+		"epochNumber":      (*hexutil.Big)(env.EpochNumber),
+		"startBlockNumber": (*hexutil.Big)(env.StartBlockNumber),
+		"endBlockNumber":   (*hexutil.Big)(env.EndBlockNumber),
+		"epochLength":      (*hexutil.Big)(env.EpochLength),
+		"numBlockMined":    (*hexutil.Big)(env.NumBlockMined),
+		"isRequest":        env.IsRequest,
+		"userActivated":    env.UserActivated,
+		"rebase":           env.Rebase,
+		"completed":        env.Completed,
+	}
+}
+
+// CurrentFork returns the current fork number of RootChain.
+func (api *PublicRootChainAPI) CurrentFork() (*hexutil.Big, error) {
+	n, err := api.p.rootchainManager.rootchainContract.CurrentFork(baseCallOpt)
+	return (*hexutil.Big)(n), err
+}
+
+// LastFinalizedBlock returns the last finalized block number of the current fork.
+func (api *PublicRootChainAPI) LastFinalizedBlock() (*hexutil.Big, error) {
+	rc := api.p.rootchainManager.rootchainContract
+
+	fork, err := rc.CurrentFork(baseCallOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := rc.GetLastFinalizedBlock(baseCallOpt, fork)
+	return (*hexutil.Big)(n), err
+}
+
+// LastFinalizedEpoch returns the last finalized epoch number of the current fork.
+func (api *PublicRootChainAPI) LastFinalizedEpoch() (*hexutil.Big, error) {
+	rc := api.p.rootchainManager.rootchainContract
+
+	fork, err := rc.CurrentFork(baseCallOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := rc.GetLastFinalizedEpoch(baseCallOpt, fork)
+	return (*hexutil.Big)(n), err
+}
+
+// GetEpoch returns the epoch of the fork. If fork is nil, the current fork is used.
+func (api *PublicRootChainAPI) GetEpoch(epochNumber hexutil.Uint64, fork *hexutil.Uint64) (map[string]interface{}, error) {
+	rc := api.p.rootchainManager.rootchainContract
+
+	forkNumber, err := api.forkNumber(fork)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := rc.GetEpoch(baseCallOpt, forkNumber, new(big.Int).SetUint64(uint64(epochNumber)))
+	if err != nil {
+		return nil, err
+	}
+
+	return RPCMarshalEpoch(forkNumber, uint64(epochNumber), epoch), nil
+}
+
+// GetBlock returns the plasma block of the fork recorded in RootChain. If
+// fork is nil, the current fork is used.
+func (api *PublicRootChainAPI) GetBlock(blockNumber hexutil.Uint64, fork *hexutil.Uint64) (map[string]interface{}, error) {
+	rc := api.p.rootchainManager.rootchainContract
+
+	forkNumber, err := api.forkNumber(fork)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := rc.GetBlock(baseCallOpt, forkNumber, new(big.Int).SetUint64(uint64(blockNumber)))
+	if err != nil {
+		return nil, err
+	}
+
+	return RPCMarshalPlasmaBlock(forkNumber, uint64(blockNumber), block), nil
+}
+
+// GetRequest returns the enter or exit request of the request id.
+func (api *PublicRootChainAPI) GetRequest(requestId hexutil.Uint64) (map[string]interface{}, error) {
+	rc := api.p.rootchainManager.rootchainContract
+
+	numRequests, err := rc.GetNumEROs(baseCallOpt)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(requestId) >= numRequests.Uint64() {
+		return nil, fmt.Errorf("request #%d does not exist", requestId)
+	}
+
+	return api.request(uint64(requestId))
+}
+
+// PendingRequests returns requests that are not finalized yet. If account is
+// given, only requests of the account are returned.
+func (api *PublicRootChainAPI) PendingRequests(account *common.Address) ([]map[string]interface{}, error) {
+	rc := api.p.rootchainManager.rootchainContract
+
+	first, err := rc.EROIdToFinalize(baseCallOpt)
+	if err != nil {
+		return nil, err
+	}
+	numRequests, err := rc.GetNumEROs(baseCallOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]map[string]interface{}, 0)
+	for id := first.Uint64(); id < numRequests.Uint64() && len(requests) < maxPendingRequests; id++ {
+		r, err := api.request(id)
+		if err != nil {
+			return nil, err
+		}
+		if account != nil && r["requestor"] != *account {
+			continue
+		}
+		requests = append(requests, r)
+	}
+
+	return requests, nil
+}
+
+// GetExits returns exit requests of the account that are not finalized yet.
+func (api *PublicRootChainAPI) GetExits(account common.Address) ([]map[string]interface{}, error) {
+	requests, err := api.PendingRequests(&account)
+	if err != nil {
+		return nil, err
+	}
+
+	exits := make([]map[string]interface{}, 0)
+	for _, r := range requests {
+		if r["isExit"].(bool) {
+			exits = append(exits, r)
+		}
+	}
+
+	return exits, nil
+}
+
+// InvalidExits returns invalid exits detected in request blocks, which are
+// challenged once the blocks are finalized.
+func (api *PublicRootChainAPI) InvalidExits() []map[string]interface{} {
+	rcm := api.p.rootchainManager
+
+	rcm.lock.Lock()
+	defer rcm.lock.Unlock()
+
+	exits := make([]map[string]interface{}, 0)
+	for _, blocks := range rcm.invalidExits {
+		for _, list := range blocks {
+			for _, e := range list {
+				exits = append(exits, map[string]interface{}{
+					"forkNumber":  (*hexutil.Big)(e.forkNumber),
+					"blockNumber": (*hexutil.Big)(e.blockNumber),
+					"index":       hexutil.Uint64(e.index),
+					"requestId":   hexutil.Uint64(e.requestId),
+					"txHash":      e.receipt.TxHash,
+					"proof":       e.proof,
+					"reason":      e.reason,
+				})
+			}
+		}
+	}
+
+	return exits
+}
+
+// FailedRequests returns reverted requests in request blocks which are not
+// challenged, with the reason of the classification.
+func (api *PublicRootChainAPI) FailedRequests() []map[string]interface{} {
+	rcm := api.p.rootchainManager
+
+	rcm.lock.Lock()
+	defer rcm.lock.Unlock()
+
+	requests := make([]map[string]interface{}, 0)
+	for _, blocks := range rcm.failedRequests {
+		for _, list := range blocks {
+			for _, r := range list {
+				requests = append(requests, map[string]interface{}{
+					"forkNumber":  (*hexutil.Big)(r.forkNumber),
+					"blockNumber": (*hexutil.Big)(r.blockNumber),
+					"index":       hexutil.Uint64(r.index),
+					"requestId":   hexutil.Uint64(r.requestId),
+					"kind":        r.kind.String(),
+					"txHash":      r.txHash,
+					"reason":      r.reason,
+				})
+			}
+		}
+	}
+
+	return requests
+}
+
+// GetReceiptProof returns the merkle proof of the transaction receipt in the
+// receipts root of its plasma block, which is used to challenge exits.
+func (api *PublicRootChainAPI) GetReceiptProof(txHash common.Hash) (map[string]interface{}, error) {
+	_, blockHash, blockNumber, index := rawdb.ReadTransaction(api.p.ChainDb(), txHash)
+	if (blockHash == common.Hash{}) {
+		return nil, errors.New("transaction not found")
+	}
+
+	receipts := api.p.blockchain.GetReceiptsByHash(blockHash)
+	if int(index) >= len(receipts) {
+		return nil, errors.New("receipt not found")
+	}
+
+	return map[string]interface{}{
+		"blockHash":   blockHash,
+		"blockNumber": hexutil.Uint64(blockNumber),
+		"index":       hexutil.Uint64(index),
+		"receipt":     hexutil.Bytes(receipts[index].GetRlp()),
+		"proof":       types.GetMerkleProof(receipts, int(index)),
+	}, nil
+}
+
+func (api *PublicRootChainAPI) forkNumber(fork *hexutil.Uint64) (*big.Int, error) {
+	if fork != nil {
+		return new(big.Int).SetUint64(uint64(*fork)), nil
+	}
+	return api.p.rootchainManager.rootchainContract.CurrentFork(baseCallOpt)
+}
+
+func (api *PublicRootChainAPI) request(id uint64) (map[string]interface{}, error) {
+	r, err := api.p.rootchainManager.rootchainContract.EROs(baseCallOpt, new(big.Int).SetUint64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"requestId":  hexutil.Uint64(id),
+		"timestamp":  hexutil.Uint64(r.Timestamp),
+		"isExit":     r.IsExit,
+		"isTransfer": r.IsTransfer,
+		"finalized":  r.Finalized,
+		"challenged": r.Challenged,
+		"value":      (*hexutil.Big)(r.Value),
+		"requestor":  r.Requestor,
+		"to":         r.To,
+		"trieKey":    common.Hash(r.TrieKey),
+		"trieValue":  hexutil.Bytes(r.TrieValue),
+		"hash":       common.Hash(r.Hash),
+	}, nil
+}
+
+// RPCMarshalEpoch converts the given epoch to the RPC output.
+func RPCMarshalEpoch(forkNumber *big.Int, epochNumber uint64, e rootchain.DataEpoch) map[string]interface{} {
+	fields := map[string]interface{}{
+		"forkNumber":       (*hexutil.Big)(forkNumber),
+		"epochNumber":      hexutil.Uint64(epochNumber),
+		"startBlockNumber": hexutil.Uint64(e.StartBlockNumber),
+		"endBlockNumber":   hexutil.Uint64(e.EndBlockNumber),
+		"timestamp":        hexutil.Uint64(e.Timestamp),
+		"isEmpty":          e.IsEmpty,
+		"initialized":      e.Initialized,
+		"isRequest":        e.IsRequest,
+		"userActivated":    e.UserActivated,
+		"rebase":           e.Rebase,
+	}
+
+	if e.IsRequest {
+		fields["requestStart"] = hexutil.Uint64(e.RE.RequestStart)
+		fields["requestEnd"] = hexutil.Uint64(e.RE.RequestEnd)
+		fields["firstRequestBlockId"] = hexutil.Uint64(e.RE.FirstRequestBlockId)
+		fields["numEnter"] = hexutil.Uint64(e.RE.NumEnter)
+	} else {
+		fields["stateRoot"] = common.Hash(e.NRE.EpochStateRoot)
+		fields["transactionsRoot"] = common.Hash(e.NRE.EpochTransactionsRoot)
+		fields["receiptsRoot"] = common.Hash(e.NRE.EpochReceiptsRoot)
+		fields["submittedAt"] = hexutil.Uint64(e.NRE.SubmittedAt)
+		fields["finalizedAt"] = hexutil.Uint64(e.NRE.FinalizedAt)
+		fields["finalized"] = e.NRE.Finalized
+	}
+
+	return fields
+}
+
+// RPCMarshalPlasmaBlock converts the given plasma block to the RPC output.
+func RPCMarshalPlasmaBlock(forkNumber *big.Int, blockNumber uint64, b rootchain.DataPlasmaBlock) map[string]interface{} {
+	return map[string]interface{}{
+		"forkNumber":       (*hexutil.Big)(forkNumber),
+		"blockNumber":      hexutil.Uint64(blockNumber),
+		"epochNumber":      hexutil.Uint64(b.EpochNumber),
+		"requestBlockId":   hexutil.Uint64(b.RequestBlockId),
+		"timestamp":        hexutil.Uint64(b.Timestamp),
+		"finalizedAt":      hexutil.Uint64(b.FinalizedAt),
+		"referenceBlock":   hexutil.Uint64(b.ReferenceBlock),
+		"stateRoot":        common.Hash(b.StatesRoot),
+		"transactionsRoot": common.Hash(b.TransactionsRoot),
+		"receiptsRoot":     common.Hash(b.ReceiptsRoot),
+		"isRequest":        b.IsRequest,
+		"userActivated":    b.UserActivated,
+		"challenged":       b.Challenged,
+		"challenging":      b.Challenging,
+		"finalized":        b.Finalized,
+	}
+}
@@ -0,0 +1,211 @@
+package pls
+
+import (
+	"errors"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/seigmanager"
+)
+
+// PublicStakingAPI provides an API to inspect TON staking state of root chains.
+type PublicStakingAPI struct {
+	p *Plasma
+}
+
+// NewPublicStakingAPI creates a new staking API.
+func NewPublicStakingAPI(p *Plasma) *PublicStakingAPI {
+	return &PublicStakingAPI{p}
+}
+
+// Seigniorage projects uncommitted seigniorage of the root chain at horizon
+// root chain blocks from now. If rootchain is nil, the root chain of this node
+// is used. If account is given, the share of the delegator is also projected.
+func (api *PublicStakingAPI) Seigniorage(rootchain *common.Address, account *common.Address, horizon *hexutil.Uint64) (map[string]interface{}, error) {
+	rcm := api.p.rootchainManager
+
+	rootchainAddr := api.p.config.RootChainContract
+	if rootchain != nil {
+		rootchainAddr = *rootchain
+	}
+
+	var n uint64
+	if horizon != nil {
+		n = uint64(*horizon)
+	}
+
+	seigManagerAddr, err := rcm.rootchainContract.SeigManager(baseCallOpt)
+	if err != nil {
+		return nil, err
+	}
+	if (seigManagerAddr == common.Address{}) {
+		return nil, errors.New("SeigManager is not registered to RootChain")
+	}
+
+	p, err := plasma.ProjectSeigniorage(rcm.backend, seigManagerAddr, rootchainAddr, account, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return RPCMarshalSeigProjection(p), nil
+}
+
+// Withdrawals returns pending withdrawal requests of the account for the root
+// chain, with the root chain block number each request becomes processable at.
+// If rootchain is nil, the root chain of this node is used.
+func (api *PublicStakingAPI) Withdrawals(account common.Address, rootchain *common.Address) (map[string]interface{}, error) {
+	rcm := api.p.rootchainManager
+
+	rootchainAddr := api.p.config.RootChainContract
+	if rootchain != nil {
+		rootchainAddr = *rootchain
+	}
+
+	depositManagerAddr, err := api.depositManager()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := plasma.ReadWithdrawalQueue(rcm.backend, depositManagerAddr, rootchainAddr, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return RPCMarshalWithdrawalQueue(q), nil
+}
+
+// PowertonRounds returns rounds of PowerTON from first to last, both inclusive.
+// If last is nil, rounds until the current round are returned. If first is nil,
+// recent rounds are returned. Rounds are read from the index of the round
+// events, and the rounds not indexed yet are left out.
+func (api *PublicStakingAPI) PowertonRounds(first *hexutil.Uint64, last *hexutil.Uint64) (map[string]interface{}, error) {
+	rcm := api.p.rootchainManager
+
+	powertonAddr, err := rcm.powertonAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := plasma.ReadPowerTONState(rcm.backend, powertonAddr)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := h.RoundRange((*uint64)(first), (*uint64)(last))
+	if err != nil {
+		return nil, err
+	}
+	h.Rounds = rcm.powertonRounds.rounds(powertonAddr, from, to)
+
+	return RPCMarshalPowerTONHistory(h), nil
+}
+
+// depositManager returns DepositManager address of the SeigManager registered to RootChain.
+func (api *PublicStakingAPI) depositManager() (common.Address, error) {
+	rcm := api.p.rootchainManager
+
+	seigManagerAddr, err := rcm.rootchainContract.SeigManager(baseCallOpt)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if (seigManagerAddr == common.Address{}) {
+		return common.Address{}, errors.New("SeigManager is not registered to RootChain")
+	}
+
+	seigManager, err := seigmanager.NewSeigManager(seigManagerAddr, rcm.backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return seigManager.DepositManager(baseCallOpt)
+}
+
+// RPCMarshalWithdrawalQueue converts the given withdrawal queue to the RPC output.
+func RPCMarshalWithdrawalQueue(q *plasma.WithdrawalQueue) map[string]interface{} {
+	requests := make([]map[string]interface{}, 0, len(q.Requests))
+	for _, r := range q.Requests {
+		requests = append(requests, map[string]interface{}{
+			"index":                   hexutil.Uint64(r.Index),
+			"amount":                  (*hexutil.Big)(r.Amount),
+			"withdrawableBlockNumber": hexutil.Uint64(r.WithdrawableBlockNumber),
+			"processable":             r.Processable,
+		})
+	}
+
+	return map[string]interface{}{
+		"rootchain":         q.RootChain,
+		"depositManager":    q.DepositManager,
+		"account":           q.Account,
+		"blockNumber":       hexutil.Uint64(q.BlockNumber),
+		"withdrawalDelay":   hexutil.Uint64(q.WithdrawalDelay),
+		"numRequests":       hexutil.Uint64(q.NumRequests),
+		"nextIndex":         hexutil.Uint64(q.NextIndex),
+		"numProcessable":    hexutil.Uint64(q.NumProcessable),
+		"pendingAmount":     (*hexutil.Big)(q.PendingAmount),
+		"processableAmount": (*hexutil.Big)(q.ProcessableAmount),
+		"requests":          requests,
+	}
+}
+
+// RPCMarshalPowerTONHistory converts the given PowerTON history to the RPC output.
+func RPCMarshalPowerTONHistory(h *plasma.PowerTONHistory) map[string]interface{} {
+	rounds := make([]map[string]interface{}, 0, len(h.Rounds))
+	for _, r := range h.Rounds {
+		rounds = append(rounds, map[string]interface{}{
+			"round":     hexutil.Uint64(r.Round),
+			"startTime": hexutil.Uint64(r.StartTime),
+			"endTime":   hexutil.Uint64(r.EndTime),
+			"reward":    (*hexutil.Big)(r.Reward),
+			"winner":    r.Winner,
+			"finished":  r.Finished,
+		})
+	}
+
+	return map[string]interface{}{
+		"powerton":      h.PowerTON,
+		"blockNumber":   hexutil.Uint64(h.BlockNumber),
+		"currentRound":  hexutil.Uint64(h.CurrentRound),
+		"roundDuration": hexutil.Uint64(h.RoundDuration),
+		"paused":        h.Paused,
+		"rounds":        rounds,
+	}
+}
+
+// RPCMarshalSeigProjection converts the given seigniorage projection to the
+// RPC output.
+func RPCMarshalSeigProjection(p *plasma.SeigProjection) map[string]interface{} {
+	fields := map[string]interface{}{
+		"rootchain":           p.RootChain,
+		"seigManager":         p.SeigManager,
+		"powerton":            p.PowerTON,
+		"operator":            p.Operator,
+		"blockNumber":         hexutil.Uint64(p.BlockNumber),
+		"horizon":             hexutil.Uint64(p.Horizon),
+		"lastSeigBlock":       hexutil.Uint64(p.LastSeigBlock),
+		"lastCommitBlock":     hexutil.Uint64(p.LastCommitBlock),
+		"paused":              p.Paused,
+		"seigPerBlock":        (*hexutil.Big)(p.SeigPerBlock),
+		"commissionRate":      (*hexutil.Big)(p.CommissionRate),
+		"powertonNumerator":   (*hexutil.Big)(p.PowerTONNumerator),
+		"powertonDenominator": (*hexutil.Big)(p.PowerTONDenominator),
+		"totalStake":          (*hexutil.Big)(p.TotalStake),
+		"totalSupply":         (*hexutil.Big)(p.TotalSupply),
+		"rootchainStake":      (*hexutil.Big)(p.RootChainStake),
+		"committedStake":      (*hexutil.Big)(p.CommittedStake),
+		"maxSeig":             (*hexutil.Big)(p.MaxSeig),
+		"stakedSeig":          (*hexutil.Big)(p.StakedSeig),
+		"unstakedSeig":        (*hexutil.Big)(p.UnstakedSeig),
+		"powertonSeig":        (*hexutil.Big)(p.PowerTONSeig),
+		"uncommittedSeig":     (*hexutil.Big)(p.UncommittedSeig),
+		"commissionSeig":      (*hexutil.Big)(p.CommissionSeig),
+		"delegatorsSeig":      (*hexutil.Big)(p.DelegatorsSeig),
+	}
+
+	if p.Account != nil {
+		fields["account"] = *p.Account
+		fields["accountStake"] = (*hexutil.Big)(p.AccountStake)
+		fields["accountSeig"] = (*hexutil.Big)(p.AccountSeig)
+	}
+
+	return fields
+}
@@ -0,0 +1,163 @@
+package pls
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/params"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+)
+
+// staminaCallGas is the gas limit of read-only calls to stamina contract.
+const staminaCallGas = 1000000
+
+// PublicStaminaAPI provides an API to inspect stamina contract of the plasma chain.
+type PublicStaminaAPI struct {
+	b *PlsAPIBackend
+}
+
+// NewPublicStaminaAPI creates a new stamina API.
+func NewPublicStaminaAPI(b *PlsAPIBackend) *PublicStaminaAPI {
+	return &PublicStaminaAPI{b}
+}
+
+// GetStamina returns stamina of the delegatee at the given block number.
+func (api *PublicStaminaAPI) GetStamina(ctx context.Context, delegatee common.Address, blockNr *rpc.BlockNumber) (*hexutil.Big, error) {
+	out := new(*big.Int)
+	err := api.call(ctx, blockNr, out, "getStamina", delegatee)
+	return (*hexutil.Big)(*out), err
+}
+
+// GetDelegatee returns the delegatee of the delegator at the given block number.
+func (api *PublicStaminaAPI) GetDelegatee(ctx context.Context, delegator common.Address, blockNr *rpc.BlockNumber) (common.Address, error) {
+	out := new(common.Address)
+	err := api.call(ctx, blockNr, out, "getDelegatee", delegator)
+	return *out, err
+}
+
+// GetDeposit returns the amount the depositor deposited to the delegatee.
+func (api *PublicStaminaAPI) GetDeposit(ctx context.Context, depositor, delegatee common.Address, blockNr *rpc.BlockNumber) (*hexutil.Big, error) {
+	out := new(*big.Int)
+	err := api.call(ctx, blockNr, out, "getDeposit", depositor, delegatee)
+	return (*hexutil.Big)(*out), err
+}
+
+// GetTotalDeposit returns the total amount deposited to the delegatee.
+func (api *PublicStaminaAPI) GetTotalDeposit(ctx context.Context, delegatee common.Address, blockNr *rpc.BlockNumber) (*hexutil.Big, error) {
+	out := new(*big.Int)
+	err := api.call(ctx, blockNr, out, "getTotalDeposit", delegatee)
+	return (*hexutil.Big)(*out), err
+}
+
+// GetRecovery returns when stamina of the delegatee was last recovered and
+// how many times it has been recovered.
+func (api *PublicStaminaAPI) GetRecovery(ctx context.Context, delegatee common.Address, blockNr *rpc.BlockNumber) (map[string]interface{}, error) {
+	lastRecoveryBlock := new(*big.Int)
+	if err := api.call(ctx, blockNr, lastRecoveryBlock, "getLastRecoveryBlock", delegatee); err != nil {
+		return nil, err
+	}
+	numRecovery := new(*big.Int)
+	if err := api.call(ctx, blockNr, numRecovery, "getNumRecovery", delegatee); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"delegatee":         delegatee,
+		"lastRecoveryBlock": (*hexutil.Big)(*lastRecoveryBlock),
+		"numRecovery":       (*hexutil.Big)(*numRecovery),
+	}, nil
+}
+
+// GetWithdrawals returns withdrawal requests of the depositor.
+func (api *PublicStaminaAPI) GetWithdrawals(ctx context.Context, depositor common.Address, blockNr *rpc.BlockNumber) ([]map[string]interface{}, error) {
+	num := new(*big.Int)
+	if err := api.call(ctx, blockNr, num, "getNumWithdrawals", depositor); err != nil {
+		return nil, err
+	}
+
+	withdrawals := make([]map[string]interface{}, 0, (*num).Uint64())
+	for i := uint64(0); i < (*num).Uint64(); i++ {
+		out := new(struct {
+			Amount             *big.Int
+			RequestBlockNumber *big.Int
+			Delegatee          common.Address
+			Processed          bool
+		})
+		if err := api.call(ctx, blockNr, out, "getWithdrawal", depositor, new(big.Int).SetUint64(i)); err != nil {
+			return nil, err
+		}
+
+		withdrawals = append(withdrawals, map[string]interface{}{
+			"index":              hexutil.Uint64(i),
+			"amount":             (*hexutil.Big)(out.Amount),
+			"requestBlockNumber": (*hexutil.Big)(out.RequestBlockNumber),
+			"delegatee":          out.Delegatee,
+			"processed":          out.Processed,
+		})
+	}
+
+	return withdrawals, nil
+}
+
+// Config returns parameters of stamina contract.
+func (api *PublicStaminaAPI) Config(ctx context.Context) (map[string]interface{}, error) {
+	minDeposit := new(*big.Int)
+	if err := api.call(ctx, nil, minDeposit, "MIN_DEPOSIT"); err != nil {
+		return nil, err
+	}
+	recoverEpochLength := new(*big.Int)
+	if err := api.call(ctx, nil, recoverEpochLength, "RECOVER_EPOCH_LENGTH"); err != nil {
+		return nil, err
+	}
+	withdrawalDelay := new(*big.Int)
+	if err := api.call(ctx, nil, withdrawalDelay, "WITHDRAWAL_DELAY"); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"address":            params.StaminaAddress,
+		"minDeposit":         (*hexutil.Big)(*minDeposit),
+		"recoverEpochLength": (*hexutil.Big)(*recoverEpochLength),
+		"withdrawalDelay":    (*hexutil.Big)(*withdrawalDelay),
+	}, nil
+}
+
+// call executes a read-only call of stamina contract on the state of the
+// block number and unpacks the result into out. If blockNr is nil, the
+// latest block is used.
+func (api *PublicStaminaAPI) call(ctx context.Context, blockNr *rpc.BlockNumber, out interface{}, method string, args ...interface{}) error {
+	number := rpc.LatestBlockNumber
+	if blockNr != nil {
+		number = *blockNr
+	}
+
+	state, header, err := api.b.StateAndHeaderByNumber(ctx, number)
+	if err != nil {
+		return err
+	}
+	if state == nil || header == nil {
+		return errors.New("block not found")
+	}
+
+	data, err := params.StaminaABI.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+
+	msg := types.NewMessage(params.NullAddress, &params.StaminaAddress, 0, new(big.Int), staminaCallGas, new(big.Int), data, false)
+	evm, _, err := api.b.GetEVM(ctx, msg, state, header)
+	if err != nil {
+		return err
+	}
+
+	ret, _, err := evm.StaticCall(params.BlockchainAccount, params.StaminaAddress, data, staminaCallGas)
+	if err != nil {
+		return err
+	}
+
+	return params.StaminaABI.Unpack(out, method, ret)
+}
@@ -27,7 +27,6 @@ import (
 
 	"github.com/Onther-Tech/plasma-evm/accounts"
 	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
-	"github.com/Onther-Tech/plasma-evm/accounts/keystore"
 	"github.com/Onther-Tech/plasma-evm/common"
 	"github.com/Onther-Tech/plasma-evm/common/hexutil"
 	"github.com/Onther-Tech/plasma-evm/consensus"
@@ -39,7 +38,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/core/rawdb"
 	"github.com/Onther-Tech/plasma-evm/core/types"
 	"github.com/Onther-Tech/plasma-evm/core/vm"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
 	"github.com/Onther-Tech/plasma-evm/ethdb"
 	"github.com/Onther-Tech/plasma-evm/event"
 	"github.com/Onther-Tech/plasma-evm/internal/plsapi"
@@ -230,7 +229,18 @@ func New(ctx *node.ServiceContext, config *Config) (*Plasma, error) {
 
 	epochEnv := rawdb.ReadEpochEnv(chainDb)
 
-	pls.miner = miner.New(pls, &config.Miner, chainConfig, pls.EventMux(), pls.engine, epochEnv, chainDb, pls.isLocalBlock)
+	// The epoch environment and the transaction manager are replicated to the
+	// standby operator through the journal.
+	var (
+		journal  *journalDB
+		plasmaDb ethdb.Database = chainDb
+	)
+	if config.OperatorLeaseFile != "" {
+		journal = newJournalDB(chainDb)
+		plasmaDb = journal
+	}
+
+	pls.miner = miner.New(pls, &config.Miner, chainConfig, pls.EventMux(), pls.engine, epochEnv, plasmaDb, pls.isLocalBlock)
 	pls.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
 	pls.APIBackend = &PlsAPIBackend{ctx.ExtRPCEnabled(), pls, nil}
@@ -241,11 +251,11 @@ func New(ctx *node.ServiceContext, config *Config) (*Plasma, error) {
 	pls.APIBackend.gpo = gasprice.NewOracle(pls.APIBackend, gpoParams)
 
 	// Dial rootchain provider
-	rootchainBackend, err := ethclient.Dial(config.RootChainURL)
+	rootchainBackend, err := failover.Dial(append([]string{config.RootChainURL}, config.RootChainFallbackURLs...)...)
 	if err != nil {
 		return nil, err
 	}
-	log.Info("Rootchain provider connected", "url", config.RootChainURL)
+	log.Info("Rootchain provider connected", "url", rootchainBackend.Status().URL, "fallbacks", len(config.RootChainFallbackURLs))
 
 	// Instantiate RootChain contract
 	rootchainContract, err := rootchain.NewRootChain(config.RootChainContract, rootchainBackend)
@@ -268,8 +278,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Plasma, error) {
 
 	stopFn := func() { pls.Stop() }
 
-	ks := ctx.AccountManager.Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
-	txManager, err := tx.NewTransactionManager(ks, rootchainBackend, chainDb, &config.TxConfig)
+	txManager, err := tx.NewTransactionManager(ctx.AccountManager, rootchainBackend, plasmaDb, &config.TxConfig)
 
 	if err != nil {
 		return nil, err
@@ -285,8 +294,10 @@ func New(ctx *node.ServiceContext, config *Config) (*Plasma, error) {
 		pls.eventMux,
 		pls.accountManager,
 		txManager,
+		plasmaDb,
 		pls.miner,
 		epochEnv,
+		journal,
 	); err != nil {
 		return nil, err
 	}
@@ -373,6 +384,31 @@ func (s *Plasma) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   NewPrivateMinerAPI(s),
 			Public:    false,
+		}, {
+			Namespace: "staking",
+			Version:   "1.0",
+			Service:   NewPublicStakingAPI(s),
+			Public:    true,
+		}, {
+			Namespace: "plasma",
+			Version:   "1.0",
+			Service:   NewPublicRootChainAPI(s),
+			Public:    true,
+		}, {
+			Namespace: "standby",
+			Version:   "1.0",
+			Service:   NewPrivateStandbyAPI(s),
+			Public:    false,
+		}, {
+			Namespace: "txManager",
+			Version:   "1.0",
+			Service:   tx.NewPrivateTxManagerAPI(s.rootchainManager.txManager),
+			Public:    false,
+		}, {
+			Namespace: "stamina",
+			Version:   "1.0",
+			Service:   NewPublicStaminaAPI(s.APIBackend),
+			Public:    true,
 		}, {
 			Namespace: "eth", // TODO: use "pls" namespace
 			Version:   "1.0",
@@ -570,6 +606,9 @@ func (s *Plasma) Downloader() *downloader.Downloader { return s.protocolManager.
 func (s *Plasma) Synced() bool                       { return atomic.LoadUint32(&s.protocolManager.acceptTxs) == 1 }
 func (s *Plasma) ArchiveMode() bool                  { return s.config.NoPruning }
 
+func (s *Plasma) NodeMode() int                       { return s.config.NodeMode }
+func (s *Plasma) RootChainManager() *RootChainManager { return s.rootchainManager }
+
 // Protocols implements node.Service, returning all the currently configured
 // network protocols to start.
 func (s *Plasma) Protocols() []p2p.Protocol {
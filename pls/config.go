@@ -41,11 +41,28 @@ const (
 	ModeChallenger
 )
 
+// NodeModeName returns the name of the node mode.
+func NodeModeName(mode int) string {
+	switch mode {
+	case ModeOperator:
+		return "operator"
+	case ModeUser:
+		return "user"
+	case ModeChallenger:
+		return "challenger"
+	default:
+		return "unknown"
+	}
+}
+
 // DefaultConfig contains default settings for use on the Ethereum main net.
 var DefaultConfig = Config{
 	NodeMode: ModeUser,
 	SyncMode: downloader.FastSync,
 	TxConfig: *tx.DefaultConfig,
+
+	OperatorLeaseTTL: 30 * time.Second,
+
 	Ethash: ethash.Config{
 		CacheDir:       "ethash",
 		CachesInMem:    2,
@@ -117,6 +134,26 @@ type Config struct {
 	RootChainContract  common.Address
 	RootChainNetworkID uint64
 
+	// Root chain providers to fail over to when the provider of RootChainURL
+	// stops responding, in the order of preference.
+	RootChainFallbackURLs []string
+
+	// Maximum number of root chain blocks between seigniorage commits. If no
+	// submission commits seigniorage in time, operator completes the current epoch
+	// with empty blocks to trigger a submission. Zero disables it.
+	SeigCommitInterval uint64
+
+	// Whether operator ends PowerTON rounds once the round duration has elapsed.
+	PowerTONKeeper bool
+
+	// Operator hot standby. Operator nodes sharing the lease file take turns
+	// sealing and submitting. A standby replicates the primary at
+	// StandbyPrimary, and takes over once the lease of the primary expires.
+	// The clocks of the nodes must be synchronized well within the lease TTL.
+	OperatorLeaseFile string
+	OperatorLeaseTTL  time.Duration
+	StandbyPrimary    string
+
 	// Protocol options
 	NetworkId uint64 // Network ID to use for selecting peers to connect to
 	SyncMode  downloader.SyncMode
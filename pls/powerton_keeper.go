@@ -0,0 +1,165 @@
+package pls
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/powerton"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/seigmanager"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/params"
+	"github.com/Onther-Tech/plasma-evm/tx"
+)
+
+const powertonCheckInterval = 30 * time.Second
+
+var powertonContractABI, _ = abi.JSON(strings.NewReader(powerton.PowerTONABI))
+
+// runPowerTONKeeper ends the current PowerTON round once its duration has
+// elapsed. endRound transactions are sent through the transaction manager
+// from the operator account.
+func (rcm *RootChainManager) runPowerTONKeeper() {
+	if rcm.config.NodeMode != ModeOperator || !rcm.config.PowerTONKeeper {
+		return
+	}
+
+	powertonAddr, err := rcm.powertonAddress()
+	if err != nil {
+		log.Error("Failed to read PowerTON address, PowerTON keeper is disabled", "err", err)
+		return
+	}
+
+	pton, err := powerton.NewPowerTON(powertonAddr, rcm.backend)
+	if err != nil {
+		log.Error("Failed to load PowerTON contract", "err", err)
+		return
+	}
+
+	log.Info("PowerTON keeper started", "powerton", powertonAddr)
+
+	confirmedCh := make(chan tx.ConfirmedEvent, 16)
+	sub := rcm.txManager.SubscribeConfirmed(confirmedCh)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(powertonCheckInterval)
+	defer ticker.Stop()
+
+	// the last round endRound transaction was added for
+	queued := int64(-1)
+
+	for {
+		select {
+		case ev := <-confirmedCh:
+			// the round is ended again unless the transaction ended it
+			if queued >= 0 && endRoundFailed(ev.Raw, uint64(queued)) {
+				log.Warn("endRound transaction failed, end the round again", "round", queued, "caption", ev.Raw.Caption,
+					"hash", ev.Raw.MinedTxHash)
+				queued = -1
+			}
+
+		case <-ticker.C:
+			round, err := pton.CurrentRound(baseCallOpt)
+			if err != nil {
+				log.Warn("Failed to read current PowerTON round", "err", err)
+				continue
+			}
+
+			if round.Int64() <= queued {
+				continue
+			}
+
+			paused, err := pton.Paused(baseCallOpt)
+			if err != nil {
+				log.Warn("Failed to read whether PowerTON is paused", "err", err)
+				continue
+			}
+			finished, err := pton.CurrentRoundFinished(baseCallOpt)
+			if err != nil {
+				log.Warn("Failed to read whether PowerTON round is finished", "err", err)
+				continue
+			}
+
+			if paused || !finished {
+				continue
+			}
+
+			if err := rcm.addEndRoundTransaction(powertonAddr, round); err != nil {
+				log.Error("Failed to add endRound transaction", "round", round, "err", err)
+				continue
+			}
+			queued = round.Int64()
+
+		case <-sub.Err():
+			return
+		case <-rcm.quit:
+			return
+		}
+	}
+}
+
+// endRoundCaption returns the caption of the endRound transaction of the round.
+func endRoundCaption(round uint64) string {
+	return fmt.Sprintf("endRound(%d)", round)
+}
+
+// endRoundFailed returns whether the confirmed raw transaction is the endRound
+// transaction of the round which is reverted or cancelled.
+func endRoundFailed(raw *tx.RawTransaction, round uint64) bool {
+	caption := endRoundCaption(round)
+	if raw.Caption == caption {
+		return raw.Reverted
+	}
+	return raw.Caption == fmt.Sprintf("cancel(%s)", caption)
+}
+
+// powertonAddress returns PowerTON address of the SeigManager registered to RootChain.
+func (rcm *RootChainManager) powertonAddress() (common.Address, error) {
+	seigManagerAddr, err := rcm.rootchainContract.SeigManager(baseCallOpt)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if (seigManagerAddr == common.Address{}) {
+		return common.Address{}, errors.New("SeigManager is not registered to RootChain")
+	}
+
+	seigManager, err := seigmanager.NewSeigManager(seigManagerAddr, rcm.backend)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	powertonAddr, err := seigManager.Powerton(baseCallOpt)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if (powertonAddr == common.Address{}) {
+		return common.Address{}, errors.New("PowerTON is not set to SeigManager")
+	}
+
+	return powertonAddr, nil
+}
+
+func (rcm *RootChainManager) addEndRoundTransaction(powertonAddr common.Address, round *big.Int) error {
+	operator := rcm.config.Operator
+	funcName := "endRound"
+
+	input, err := powertonContractABI.Pack(funcName)
+	if err != nil {
+		return err
+	}
+
+	caption := endRoundCaption(round.Uint64())
+	rawTx := tx.NewRawTransaction(operator.Address, params.EndRoundGasLimit, &powertonAddr, big.NewInt(0), input, false, caption)
+
+	// endRound has no argument, so raw transactions for every round but the first are duplicates
+	err = rcm.txManager.Add(operator, rawTx, false)
+	if err == tx.ErrDuplicateRaw {
+		err = rcm.txManager.Add(operator, rawTx, true)
+	}
+
+	return err
+}
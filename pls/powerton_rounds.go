@@ -0,0 +1,235 @@
+package pls
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/powerton"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/ethdb"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/params"
+	"github.com/Onther-Tech/plasma-evm/rlp"
+	"github.com/Onther-Tech/plasma-evm/tx"
+)
+
+var (
+	powertonRoundPrefix   = []byte("powerton-round")   // powertonRoundPrefix + powerton address + round (uint64 big endian) -> round
+	powertonIndexedPrefix = []byte("powerton-indexed") // powertonIndexedPrefix + powerton address -> last indexed root chain block
+)
+
+var (
+	roundStartEventID = powertonContractABI.Events["RoundStart"].ID()
+	roundEndEventID   = powertonContractABI.Events["RoundEnd"].ID()
+)
+
+// powertonRounds persists the PowerTON rounds indexed from the RoundStart and
+// RoundEnd events, so the round history is not read from the contract state.
+type powertonRounds struct {
+	db   ethdb.Database
+	lock sync.Mutex
+}
+
+func newPowerTONRounds(db ethdb.Database) *powertonRounds {
+	return &powertonRounds{db: db}
+}
+
+func powertonRoundKey(addr common.Address, round uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, round)
+	return append(append(append([]byte{}, powertonRoundPrefix...), addr.Bytes()...), enc...)
+}
+
+func powertonIndexedKey(addr common.Address) []byte {
+	return append(append([]byte{}, powertonIndexedPrefix...), addr.Bytes()...)
+}
+
+// indexed returns the last root chain block whose PowerTON events are indexed.
+func (r *powertonRounds) indexed(addr common.Address) (uint64, bool) {
+	data, _ := r.db.Get(powertonIndexedKey(addr))
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// round returns the indexed round, or nil if the round is not indexed.
+func (r *powertonRounds) round(addr common.Address, n uint64) *plasma.PowerTONRound {
+	data, _ := r.db.Get(powertonRoundKey(addr, n))
+	if len(data) == 0 {
+		return nil
+	}
+	round := new(plasma.PowerTONRound)
+	if err := rlp.DecodeBytes(data, round); err != nil {
+		log.Error("Invalid PowerTON round RLP", "round", n, "err", err)
+		return nil
+	}
+	return round
+}
+
+// rounds returns the indexed rounds from first to last, both inclusive. Rounds
+// which are not indexed are left out.
+func (r *powertonRounds) rounds(addr common.Address, first, last uint64) []*plasma.PowerTONRound {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var rounds []*plasma.PowerTONRound
+	for n := first; n <= last; n++ {
+		if round := r.round(addr, n); round != nil {
+			rounds = append(rounds, round)
+		}
+	}
+	return rounds
+}
+
+// apply indexes the PowerTON logs of the root chain blocks up to number.
+// Finished of an indexed round is whether the round is ended.
+func (r *powertonRounds) apply(addr common.Address, logs []types.Log, number uint64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	filterer, err := powerton.NewPowerTONFilterer(addr, nil)
+	if err != nil {
+		return err
+	}
+
+	batch := r.db.NewBatch()
+	updated := make(map[uint64]*plasma.PowerTONRound)
+	get := func(n uint64) *plasma.PowerTONRound {
+		if round, ok := updated[n]; ok {
+			return round
+		}
+		round := r.round(addr, n)
+		if round == nil {
+			round = &plasma.PowerTONRound{Round: n, Reward: new(big.Int)}
+		}
+		updated[n] = round
+		return round
+	}
+
+	for _, l := range logs {
+		if len(l.Topics) == 0 {
+			continue
+		}
+		switch l.Topics[0] {
+		case roundStartEventID:
+			ev, err := filterer.ParseRoundStart(l)
+			if err != nil {
+				return err
+			}
+			round := get(ev.Round.Uint64())
+			round.StartTime, round.EndTime = ev.StartTime.Uint64(), ev.EndTime.Uint64()
+
+		case roundEndEventID:
+			ev, err := filterer.ParseRoundEnd(l)
+			if err != nil {
+				return err
+			}
+			round := get(ev.Round.Uint64())
+			round.Winner, round.Reward, round.Finished = ev.Winner, ev.Reward, true
+
+			log.Info("PowerTON round ended", "round", ev.Round, "winner", ev.Winner, "reward", params.ToRayFloat64(ev.Reward))
+		}
+	}
+
+	for n, round := range updated {
+		data, err := rlp.EncodeToBytes(round)
+		if err != nil {
+			return err
+		}
+		batch.Put(powertonRoundKey(addr, n), data)
+	}
+
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, number)
+	batch.Put(powertonIndexedKey(addr), enc)
+
+	return batch.Write()
+}
+
+// runPowerTONIndexer indexes the rounds of the PowerTON of the SeigManager
+// registered to RootChain. Only the root chain blocks with enough
+// confirmations are indexed.
+func (rcm *RootChainManager) runPowerTONIndexer() {
+	ticker := time.NewTicker(powertonCheckInterval)
+	defer ticker.Stop()
+
+	var powertonAddr common.Address
+
+	for {
+		if (powertonAddr == common.Address{}) {
+			if addr, err := rcm.powertonAddress(); err != nil {
+				log.Debug("PowerTON is not indexed", "err", err)
+			} else {
+				powertonAddr = addr
+				log.Info("PowerTON rounds are indexed", "powerton", powertonAddr)
+			}
+		}
+
+		if (powertonAddr != common.Address{}) {
+			if err := rcm.indexPowerTONRounds(powertonAddr); err != nil {
+				log.Warn("Failed to index PowerTON rounds", "err", err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-rcm.quit:
+			return
+		}
+	}
+}
+
+func (rcm *RootChainManager) indexPowerTONRounds(addr common.Address) error {
+	header, err := rcm.backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	if header.Number.Uint64() < tx.ConfirmationDelay {
+		return nil
+	}
+	head := header.Number.Uint64() - tx.ConfirmationDelay
+
+	// index from the block RootChain is deployed in, PowerTON is deployed after it
+	var next uint64
+	if indexed, ok := rcm.powertonRounds.indexed(addr); ok {
+		next = indexed + 1
+	} else if next, err = rcm.deploymentBlock(); err != nil {
+		return err
+	}
+
+	for next <= head {
+		to := next + scanBlockRange - 1
+		if to > head {
+			to = head
+		}
+
+		logs, err := rcm.backend.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(next),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{addr},
+			Topics:    [][]common.Hash{{roundStartEventID, roundEndEventID}},
+		})
+		if err != nil {
+			return err
+		}
+		if err := rcm.powertonRounds.apply(addr, logs, to); err != nil {
+			return err
+		}
+
+		select {
+		case <-rcm.quit:
+			return nil
+		default:
+		}
+		next = to + 1
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package pls
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/tx"
+)
+
+func powertonTestLog(t *testing.T, name string, args ...interface{}) types.Log {
+	ev := powertonContractABI.Events[name]
+	data, err := ev.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatalf("failed to pack %s: %v", name, err)
+	}
+	return types.Log{Topics: []common.Hash{ev.ID()}, Data: data}
+}
+
+func TestPowerTONRounds(t *testing.T) {
+	var (
+		addr   = common.HexToAddress("0x1")
+		winner = common.HexToAddress("0x2")
+		rounds = newPowerTONRounds(rawdb.NewMemoryDatabase())
+	)
+
+	if _, ok := rounds.indexed(addr); ok {
+		t.Fatal("empty index has indexed block")
+	}
+
+	// round 0 starts and ends, round 1 starts
+	logs := []types.Log{
+		powertonTestLog(t, "RoundStart", big.NewInt(0), big.NewInt(100), big.NewInt(200)),
+		powertonTestLog(t, "RoundEnd", big.NewInt(0), winner, big.NewInt(7)),
+		powertonTestLog(t, "RoundStart", big.NewInt(1), big.NewInt(200), big.NewInt(300)),
+	}
+	if err := rounds.apply(addr, logs, 10); err != nil {
+		t.Fatalf("failed to index rounds: %v", err)
+	}
+	if number, ok := rounds.indexed(addr); !ok || number != 10 {
+		t.Fatalf("indexed block mismatch: have %d, want 10", number)
+	}
+
+	have := rounds.rounds(addr, 0, 5)
+	if len(have) != 2 {
+		t.Fatalf("rounds mismatch: have %d, want 2", len(have))
+	}
+	if r := have[0]; r.StartTime != 100 || r.EndTime != 200 || r.Winner != winner || r.Reward.Int64() != 7 || !r.Finished {
+		t.Errorf("round 0 mismatch: %+v", r)
+	}
+	if r := have[1]; r.StartTime != 200 || r.Finished || r.Reward.Sign() != 0 {
+		t.Errorf("round 1 mismatch: %+v", r)
+	}
+
+	// round 1 ends in a later range
+	if err := rounds.apply(addr, []types.Log{powertonTestLog(t, "RoundEnd", big.NewInt(1), winner, big.NewInt(9))}, 20); err != nil {
+		t.Fatalf("failed to index rounds: %v", err)
+	}
+	if r := rounds.round(addr, 1); r == nil || r.StartTime != 200 || r.Reward.Int64() != 9 || !r.Finished {
+		t.Errorf("ended round 1 mismatch: %+v", r)
+	}
+
+	// rounds of other PowerTON are not mixed
+	if other := rounds.rounds(common.HexToAddress("0x3"), 0, 5); len(other) != 0 {
+		t.Errorf("rounds of other PowerTON: %d", len(other))
+	}
+}
+
+func TestEndRoundFailed(t *testing.T) {
+	tests := []struct {
+		caption  string
+		reverted bool
+		failed   bool
+	}{
+		{"endRound(3)", false, false},
+		{"endRound(3)", true, true},
+		{"cancel(endRound(3))", false, true},
+		{"endRound(2)", true, false},
+		{"submitNRE(3: [5-8])", true, false},
+	}
+	for _, tt := range tests {
+		raw := &tx.RawTransaction{Caption: tt.caption, Reverted: tt.reverted}
+		if failed := endRoundFailed(raw, 3); failed != tt.failed {
+			t.Errorf("%s (reverted: %v): failed mismatch: have %v, want %v", tt.caption, tt.reverted, failed, tt.failed)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package pls
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/log"
+)
+
+const (
+	// undoLookback is the number of preceding requests searched for the
+	// challenged exit request which an enter request undoes.
+	undoLookback = 32
+
+	// requestLookupRetries is the number of attempts to read a reverted
+	// request from RootChain before it is challenged as an exit request.
+	requestLookupRetries    = 3
+	requestLookupRetryDelay = time.Second
+)
+
+var errNotRequestTx = errors.New("not a request transaction")
+
+// requestKind is the kind of a request applied by a request transaction.
+type requestKind uint8
+
+const (
+	requestEnter requestKind = iota
+	requestExit
+	requestUndo // enter request made to undo a challenged exit request
+)
+
+func (k requestKind) String() string {
+	switch k {
+	case requestEnter:
+		return "enter"
+	case requestExit:
+		return "exit"
+	case requestUndo:
+		return "undo"
+	}
+	return "unknown"
+}
+
+// failedRequest is a reverted request transaction which is not challenged.
+type failedRequest struct {
+	forkNumber  *big.Int
+	blockNumber *big.Int
+	index       int64
+	requestId   uint64
+	kind        requestKind
+	reason      string
+	txHash      common.Hash
+}
+
+// ero is the subset of an ERO or ERU which is used to classify requests.
+type ero struct {
+	IsExit     bool
+	IsTransfer bool
+	Challenged bool
+	Requestor  common.Address
+	To         common.Address
+	TrieKey    [32]byte
+}
+
+// requestId returns the ERO id applied by the request transaction. Ether
+// transfers carry no request id and are always enter requests.
+func requestId(tx *types.Transaction) (uint64, bool, error) {
+	data := tx.Data()
+	if len(data) == 0 {
+		return 0, false, nil
+	}
+
+	method, ok := requestableContractABI.Methods["applyRequestInChildChain"]
+	if !ok || len(data) < 4 || string(data[:4]) != string(method.ID()) {
+		return 0, false, errNotRequestTx
+	}
+	args, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return 0, false, err
+	}
+	id, ok := args[1].(*big.Int)
+	if !ok {
+		return 0, false, errNotRequestTx
+	}
+	return id.Uint64(), true, nil
+}
+
+// getRequest reads the ERU of the user activated request block, or the ERO
+// otherwise, from RootChain.
+func (rcm *RootChainManager) getRequest(opts *bind.CallOpts, userActivated bool, id uint64) (*ero, error) {
+	read := rcm.rootchainContract.EROs
+	if userActivated {
+		read = rcm.rootchainContract.ERUs
+	}
+
+	r, err := read(opts, new(big.Int).SetUint64(id))
+	if err != nil {
+		return nil, err
+	}
+	return &ero{
+		IsExit:     r.IsExit,
+		IsTransfer: r.IsTransfer,
+		Challenged: r.Challenged,
+		Requestor:  r.Requestor,
+		To:         r.To,
+		TrieKey:    r.TrieKey,
+	}, nil
+}
+
+// classifyRequest maps the request transaction back to its ERO, or ERU if
+// the request block is user activated, and returns the kind of the request.
+func (rcm *RootChainManager) classifyRequest(opts *bind.CallOpts, userActivated bool, tx *types.Transaction) (uint64, requestKind, error) {
+	id, ok, err := requestId(tx)
+	if err != nil {
+		return 0, requestEnter, err
+	}
+	if !ok {
+		return 0, requestEnter, nil
+	}
+
+	r, err := rcm.getRequest(opts, userActivated, id)
+	if err != nil {
+		return id, requestEnter, err
+	}
+	if r.IsExit {
+		return id, requestExit, nil
+	}
+
+	// an enter request undoes the latest challenged exit of the same trie key
+	for prev := id; prev > 0 && id-prev < undoLookback; {
+		prev--
+		p, err := rcm.getRequest(opts, userActivated, prev)
+		if err != nil {
+			return id, requestEnter, err
+		}
+		if p.IsExit && p.Requestor == r.Requestor && p.To == r.To && p.TrieKey == r.TrieKey {
+			if p.Challenged {
+				return id, requestUndo, nil
+			}
+			break
+		}
+	}
+	return id, requestEnter, nil
+}
+
+// retryLookup calls the root chain lookup until it succeeds, up to
+// requestLookupRetries times.
+func retryLookup(lookup func() error) (err error) {
+	for attempt := 0; attempt < requestLookupRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(requestLookupRetryDelay)
+		}
+		if err = lookup(); err == nil {
+			return nil
+		}
+		log.Warn("Failed to read request from root chain", "attempt", attempt+1, "err", err)
+	}
+	return err
+}
+
+// checkFailedRequest classifies the reverted request transaction, retrying
+// the root chain lookup. It returns the invalid exit to be challenged, or the
+// failed request with the reason why it is not challenged.
+func (rcm *RootChainManager) checkFailedRequest(opts *bind.CallOpts, forkNumber *big.Int, userActivated bool, block *types.Block, receipts types.Receipts, i int) (*invalidExit, *failedRequest) {
+	var (
+		id   uint64
+		kind requestKind
+	)
+	err := retryLookup(func() (err error) {
+		id, kind, err = rcm.classifyRequest(opts, userActivated, block.Transactions()[i])
+		return err
+	})
+	return classifyFailedRequest(forkNumber, block, receipts, i, id, kind, err)
+}
+
+// classifyFailedRequest returns the invalid exit or the failed request of the
+// reverted request transaction of the kind. A request which could not be read
+// from RootChain is challenged as an exit request, since an invalid exit must
+// not be left unchallenged.
+func classifyFailedRequest(forkNumber *big.Int, block *types.Block, receipts types.Receipts, i int, id uint64, kind requestKind, lookupErr error) (*invalidExit, *failedRequest) {
+	tx := block.Transactions()[i]
+
+	// A request which consumed all of its gas ran out of gas rather than being
+	// rejected by the requestable contract.
+	outOfGas := receipts[i].GasUsed >= tx.Gas()
+	if outOfGas {
+		requestOutOfGasMeter.Mark(1)
+	}
+
+	if lookupErr == nil && kind != requestExit {
+		failed := &failedRequest{
+			forkNumber:  forkNumber,
+			blockNumber: block.Number(),
+			index:       int64(i),
+			requestId:   id,
+			kind:        kind,
+			reason:      fmt.Sprintf("%s request reverted in child chain", kind),
+			txHash:      receipts[i].TxHash,
+		}
+		if outOfGas {
+			failed.reason = fmt.Sprintf("out of gas (gas limit %d)", tx.Gas())
+		}
+		return nil, failed
+	}
+
+	reason := "exit request reverted in child chain"
+	switch {
+	case lookupErr != nil:
+		reason = fmt.Sprintf("failed to read request, challenged as exit: %v", lookupErr)
+	case outOfGas:
+		reason = fmt.Sprintf("exit request ran out of gas (gas limit %d)", tx.Gas())
+	}
+
+	return &invalidExit{
+		forkNumber:  forkNumber,
+		blockNumber: block.Number(),
+		receipt:     receipts[i],
+		index:       int64(i),
+		proof:       types.GetMerkleProof(receipts, i),
+		requestId:   id,
+		reason:      reason,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package pls
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+)
+
+// makeRevertedRequest returns a request block with a request transaction
+// reverted after using gasUsed.
+func makeRevertedRequest(gasLimit, gasUsed uint64) (*types.Block, types.Receipts) {
+	tx := types.NewTransaction(0, common.HexToAddress("0x01"), big.NewInt(0), gasLimit, big.NewInt(0), nil)
+	receipt := &types.Receipt{Status: types.ReceiptStatusFailed, GasUsed: gasUsed, TxHash: tx.Hash()}
+	receipts := types.Receipts{receipt}
+
+	header := &types.Header{Number: big.NewInt(1)}
+	return types.NewBlock(header, types.Transactions{tx}, nil, receipts), receipts
+}
+
+func TestClassifyFailedRequest(t *testing.T) {
+	const gasLimit = 100000
+	forkNumber := big.NewInt(0)
+
+	tests := []struct {
+		name      string
+		gasUsed   uint64
+		kind      requestKind
+		lookupErr error
+		challenge bool
+	}{
+		{"reverted exit", gasLimit / 2, requestExit, nil, true},
+		{"exit out of gas", gasLimit, requestExit, nil, true},
+		{"lookup failure", gasLimit / 2, requestEnter, errors.New("connection refused"), true},
+		{"reverted enter", gasLimit / 2, requestEnter, nil, false},
+		{"enter out of gas", gasLimit, requestEnter, nil, false},
+		{"reverted undo", gasLimit / 2, requestUndo, nil, false},
+	}
+	for _, tt := range tests {
+		block, receipts := makeRevertedRequest(gasLimit, tt.gasUsed)
+
+		invalidExit, failed := classifyFailedRequest(forkNumber, block, receipts, 0, 1, tt.kind, tt.lookupErr)
+		if tt.challenge {
+			if invalidExit == nil || failed != nil {
+				t.Errorf("%s: not challenged, failed request %+v", tt.name, failed)
+			}
+			continue
+		}
+		if invalidExit != nil || failed == nil {
+			t.Errorf("%s: challenged, want failed request", tt.name)
+			continue
+		}
+		if failed.kind != tt.kind || failed.requestId != 1 {
+			t.Errorf("%s: failed request mismatch: have %v #%d, want %v #1", tt.name, failed.kind, failed.requestId, tt.kind)
+		}
+	}
+}
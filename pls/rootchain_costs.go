@@ -0,0 +1,312 @@
+package pls
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/ethdb"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/rlp"
+	"github.com/Onther-Tech/plasma-evm/tx"
+)
+
+var (
+	numCostEntriesKey = []byte("num-rootchain-costs") // numCostEntriesKey -> the number of cost entries
+	costEntryPrefix   = []byte("rootchain-cost")      // costEntryPrefix + i (uint64 big endian) -> i-th cost entry
+)
+
+// costDateLayout is the layout of the dates the costs are aggregated by.
+const costDateLayout = "2006-01-02"
+
+// CostEntry is the cost of a confirmed root chain transaction of the node.
+type CostEntry struct {
+	From    common.Address
+	Caption string
+
+	// plasma epoch and blocks submitted by the transaction, zero if it is not
+	// a submission
+	Epoch      uint64
+	StartBlock uint64
+	EndBlock   uint64
+
+	TxHash      common.Hash
+	BlockNumber uint64 // root chain block number the transaction is mined in
+	Time        uint64 // root chain block timestamp
+
+	GasUsed   uint64
+	GasPrice  *big.Int
+	Value     *big.Int // ether sent with the transaction
+	Recovered *big.Int // costNRB or costORB recovered from the users
+	Reverted  bool
+}
+
+// Fee returns the ether paid for the gas.
+func (e *CostEntry) Fee() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(e.GasUsed), e.GasPrice)
+}
+
+// Spent returns the ether spent by the transaction. The value is returned to
+// the sender if the transaction is reverted.
+func (e *CostEntry) Spent() *big.Int {
+	if e.Reverted {
+		return e.Fee()
+	}
+	return new(big.Int).Add(e.Fee(), e.Value)
+}
+
+// DailyCost is the sum of the costs of a day in UTC.
+type DailyCost struct {
+	Date      string
+	Count     uint64
+	Reverted  uint64
+	GasUsed   uint64
+	Fee       *big.Int
+	Spent     *big.Int
+	Recovered *big.Int
+}
+
+// Net returns the ether spent but not recovered.
+func (d *DailyCost) Net() *big.Int {
+	return new(big.Int).Sub(d.Spent, d.Recovered)
+}
+
+// costLedger persists the costs of the confirmed root chain transactions to
+// bill and budget the operation.
+type costLedger struct {
+	db   ethdb.Database
+	lock sync.Mutex
+}
+
+func newCostLedger(db ethdb.Database) *costLedger {
+	return &costLedger{db: db}
+}
+
+func encodeCostIndex(i uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, i)
+	return enc
+}
+
+func costEntryKey(i uint64) []byte {
+	return append(append([]byte{}, costEntryPrefix...), encodeCostIndex(i)...)
+}
+
+func (l *costLedger) count() uint64 {
+	data, _ := l.db.Get(numCostEntriesKey)
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// record appends the entry to the ledger.
+func (l *costLedger) record(e *CostEntry) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	data, err := rlp.EncodeToBytes(e)
+	if err != nil {
+		log.Crit("Failed to encode cost entry", "err", err)
+	}
+
+	n := l.count()
+	batch := l.db.NewBatch()
+	batch.Put(costEntryKey(n), data)
+	batch.Put(numCostEntriesKey, encodeCostIndex(n+1))
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to store cost entry", "err", err)
+	}
+}
+
+// entries returns the entries mined in [from, to) in unix time.
+func (l *costLedger) entries(from, to uint64) []*CostEntry {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var entries []*CostEntry
+	for i, n := uint64(0), l.count(); i < n; i++ {
+		data, _ := l.db.Get(costEntryKey(i))
+		if len(data) == 0 {
+			continue
+		}
+		e := new(CostEntry)
+		if err := rlp.DecodeBytes(data, e); err != nil {
+			log.Error("Invalid cost entry", "index", i, "err", err)
+			continue
+		}
+		if e.Time >= from && e.Time < to {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// dailyCosts sums the entries by day in the order of time.
+func dailyCosts(entries []*CostEntry) []*DailyCost {
+	var (
+		days   []*DailyCost
+		byDate = make(map[string]*DailyCost)
+	)
+	for _, e := range entries {
+		date := time.Unix(int64(e.Time), 0).UTC().Format(costDateLayout)
+
+		day, ok := byDate[date]
+		if !ok {
+			day = &DailyCost{Date: date, Fee: new(big.Int), Spent: new(big.Int), Recovered: new(big.Int)}
+			byDate[date] = day
+			days = append(days, day)
+		}
+		day.Count++
+		if e.Reverted {
+			day.Reverted++
+		}
+		day.GasUsed += e.GasUsed
+		day.Fee.Add(day.Fee, e.Fee())
+		day.Spent.Add(day.Spent, e.Spent())
+		day.Recovered.Add(day.Recovered, e.Recovered)
+	}
+	return days
+}
+
+// writeCostsCSV writes the entries in CSV with a header line. Amounts are in wei.
+func writeCostsCSV(w io.Writer, entries []*CostEntry) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "from", "caption", "epoch", "startBlock", "endBlock", "rootchainBlock", "txHash",
+		"gasUsed", "gasPrice", "fee", "value", "spent", "recovered", "reverted"})
+
+	for _, e := range entries {
+		cw.Write([]string{
+			time.Unix(int64(e.Time), 0).UTC().Format(time.RFC3339),
+			e.From.Hex(),
+			e.Caption,
+			strconv.FormatUint(e.Epoch, 10),
+			strconv.FormatUint(e.StartBlock, 10),
+			strconv.FormatUint(e.EndBlock, 10),
+			strconv.FormatUint(e.BlockNumber, 10),
+			e.TxHash.Hex(),
+			strconv.FormatUint(e.GasUsed, 10),
+			e.GasPrice.String(),
+			e.Fee().String(),
+			e.Value.String(),
+			e.Spent().String(),
+			e.Recovered.String(),
+			strconv.FormatBool(e.Reverted),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseSubmitCaption returns the epoch and blocks submitted by the raw
+// transaction of the caption, e.g. "submitNRE(1: [1-4])" or "submitORB(2: 5)".
+func parseSubmitCaption(caption string) (kind string, epoch, start, end uint64) {
+	i := strings.IndexByte(caption, '(')
+	if i < 0 {
+		return caption, 0, 0, 0
+	}
+	kind, args := caption[:i], caption[i+1:]
+
+	switch kind {
+	case "submitNRE":
+		fmt.Sscanf(args, "%d: [%d-%d])", &epoch, &start, &end)
+	case "submitORB":
+		fmt.Sscanf(args, "%d: %d)", &epoch, &start)
+		end = start
+	}
+	return kind, epoch, start, end
+}
+
+// costEntry returns the ledger entry of the confirmed raw transaction.
+func (rcm *RootChainManager) costEntry(ev tx.ConfirmedEvent) (*CostEntry, error) {
+	raw := ev.Raw
+
+	header, err := rcm.backend.HeaderByNumber(context.Background(), raw.MinedBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cost is looked up if the raw transaction is mined before restart
+	gasUsed, gasPrice := ev.GasUsed, ev.GasPrice
+	if gasPrice == nil {
+		receipt, err := rcm.backend.TransactionReceipt(context.Background(), raw.MinedTxHash)
+		if err != nil {
+			return nil, err
+		}
+		block, err := rcm.backend.BlockByHash(context.Background(), receipt.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		mined := block.Transaction(raw.MinedTxHash)
+		if mined == nil {
+			return nil, fmt.Errorf("transaction %s not found in block %d", raw.MinedTxHash.Hex(), receipt.BlockNumber)
+		}
+		gasUsed, gasPrice = receipt.GasUsed, mined.GasPrice()
+	}
+
+	kind, epoch, start, end := parseSubmitCaption(raw.Caption)
+
+	recovered := new(big.Int)
+	if !raw.Reverted {
+		switch kind {
+		case "submitNRE":
+			recovered.SetUint64(rcm.state.costNRB)
+		case "submitORB":
+			recovered.SetUint64(rcm.state.costORB)
+		}
+	}
+
+	value := new(big.Int)
+	if raw.Amount != nil {
+		value.Set(raw.Amount)
+	}
+
+	return &CostEntry{
+		From:        raw.From,
+		Caption:     raw.Caption,
+		Epoch:       epoch,
+		StartBlock:  start,
+		EndBlock:    end,
+		TxHash:      raw.MinedTxHash,
+		BlockNumber: header.Number.Uint64(),
+		Time:        header.Time,
+		GasUsed:     gasUsed,
+		GasPrice:    gasPrice,
+		Value:       value,
+		Recovered:   recovered,
+		Reverted:    raw.Reverted,
+	}, nil
+}
+
+// runCostLedger records the costs of the confirmed root chain transactions.
+func (rcm *RootChainManager) runCostLedger() {
+	confirmedCh := make(chan tx.ConfirmedEvent, 16)
+	sub := rcm.txManager.SubscribeConfirmed(confirmedCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-confirmedCh:
+			e, err := rcm.costEntry(ev)
+			if err != nil {
+				log.Error("Failed to record root chain transaction cost", "caption", ev.Raw.Caption, "hash", ev.Raw.MinedTxHash, "err", err)
+				continue
+			}
+			rcm.costs.record(e)
+			log.Debug("Root chain transaction cost recorded", "caption", e.Caption, "spent", e.Spent(), "recovered", e.Recovered)
+
+		case <-sub.Err():
+			return
+		case <-rcm.quit:
+			return
+		}
+	}
+}
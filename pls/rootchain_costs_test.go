@@ -0,0 +1,73 @@
+package pls
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+)
+
+func TestParseSubmitCaption(t *testing.T) {
+	tests := []struct {
+		caption           string
+		kind              string
+		epoch, start, end uint64
+	}{
+		{"submitNRE(3: [5-8])", "submitNRE", 3, 5, 8},
+		{"submitORB(4: 9)", "submitORB", 4, 9, 9},
+		{"challengeExit(2: 1)", "challengeExit", 0, 0, 0},
+		{"endRound", "endRound", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		kind, epoch, start, end := parseSubmitCaption(tt.caption)
+		if kind != tt.kind || epoch != tt.epoch || start != tt.start || end != tt.end {
+			t.Errorf("%s: have %s %d [%d-%d], want %s %d [%d-%d]", tt.caption, kind, epoch, start, end, tt.kind, tt.epoch, tt.start, tt.end)
+		}
+	}
+}
+
+func TestCostLedger(t *testing.T) {
+	ledger := newCostLedger(rawdb.NewMemoryDatabase())
+
+	const day = 24 * 60 * 60
+	entries := []*CostEntry{
+		{Caption: "submitNRE(1: [1-4])", Time: day, GasUsed: 100, GasPrice: big.NewInt(2), Value: big.NewInt(10), Recovered: big.NewInt(10)},
+		{Caption: "submitORB(2: 5)", Time: day + 1, GasUsed: 50, GasPrice: big.NewInt(2), Value: big.NewInt(10), Recovered: big.NewInt(0), Reverted: true},
+		{Caption: "submitNRE(2: [6-9])", Time: 2 * day, GasUsed: 100, GasPrice: big.NewInt(3), Value: big.NewInt(10), Recovered: big.NewInt(10)},
+	}
+	for _, e := range entries {
+		ledger.record(e)
+	}
+
+	if have := ledger.entries(0, 2*day); len(have) != 2 {
+		t.Fatalf("entries mismatch: have %d, want %d", len(have), 2)
+	}
+
+	days := dailyCosts(ledger.entries(0, 3*day))
+	if len(days) != 2 {
+		t.Fatalf("days mismatch: have %d, want %d", len(days), 2)
+	}
+	// the value of the reverted transaction is not spent
+	first := days[0]
+	if first.Date != "1970-01-02" || first.Count != 2 || first.Reverted != 1 || first.GasUsed != 150 ||
+		first.Fee.Int64() != 300 || first.Spent.Int64() != 310 || first.Net().Int64() != 300 {
+		t.Errorf("first day mismatch: %+v", first)
+	}
+	if second := days[1]; second.Date != "1970-01-03" || second.Spent.Int64() != 310 || second.Net().Int64() != 300 {
+		t.Errorf("second day mismatch: %+v", second)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCostsCSV(&buf, ledger.entries(0, 3*day)); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	if len(records) != 4 || records[1][2] != "submitNRE(1: [1-4])" || records[1][12] != "210" {
+		t.Errorf("CSV mismatch: %v", records)
+	}
+}
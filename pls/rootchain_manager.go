@@ -13,28 +13,51 @@ import (
 	"github.com/Onther-Tech/plasma-evm/accounts/abi"
 	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
 	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
 	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
 	"github.com/Onther-Tech/plasma-evm/core"
 	"github.com/Onther-Tech/plasma-evm/core/types"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
+	"github.com/Onther-Tech/plasma-evm/ethdb"
 	"github.com/Onther-Tech/plasma-evm/event"
 	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/metrics"
 	"github.com/Onther-Tech/plasma-evm/miner"
 	"github.com/Onther-Tech/plasma-evm/miner/epoch"
 	"github.com/Onther-Tech/plasma-evm/params"
 	"github.com/Onther-Tech/plasma-evm/tx"
 )
 
-const (
-	MAX_EPOCH_EVENTS = 0
-)
-
 var (
 	baseCallOpt               = &bind.CallOpts{Pending: false, Context: context.Background()}
 	requestableContractABI, _ = abi.JSON(strings.NewReader(rootchain.RequestableIABI))
 	rootchainContractABI, _   = abi.JSON(strings.NewReader(rootchain.RootChainABI))
 
+	epochPreparedEventID  = rootchainContractABI.Events["EpochPrepared"].ID()
+	blockFinalizedEventID = rootchainContractABI.Events["BlockFinalized"].ID()
+	forkedEventID         = rootchainContractABI.Events["Forked"].ID()
+
 	ErrKnownTransaction = errors.New("known transaction")
+	errORBGasExceeded   = errors.New("request block exceeds gas limit")
+)
+
+var (
+	blockMinedMeter         = metrics.NewRegisteredMeter("pls/blocks/mined", nil)
+	blockSubmittedMeter     = metrics.NewRegisteredMeter("pls/blocks/submitted", nil)
+	blockFinalizedMeter     = metrics.NewRegisteredMeter("pls/blocks/finalized", nil)
+	lastMinedBlockGauge     = metrics.NewRegisteredGauge("pls/blocks/lastmined", nil)
+	lastSubmittedBlockGauge = metrics.NewRegisteredGauge("pls/blocks/lastsubmitted", nil)
+	lastFinalizedBlockGauge = metrics.NewRegisteredGauge("pls/blocks/lastfinalized", nil)
+	submitDelayTimer        = metrics.NewRegisteredTimer("pls/submit/delay", nil) // from block timestamp to submission queued
+	invalidExitMeter        = metrics.NewRegisteredMeter("pls/challenge/invalidexits", nil)
+	requestOutOfGasMeter    = metrics.NewRegisteredMeter("pls/challenge/outofgas", nil)
+	failedRequestMeter      = metrics.NewRegisteredMeter("pls/challenge/failedrequests", nil)
+	challengeSentMeter      = metrics.NewRegisteredMeter("pls/challenge/sent", nil)
+	challengeFailedMeter    = metrics.NewRegisteredMeter("pls/challenge/failed", nil)
+	challengeLateMeter      = metrics.NewRegisteredMeter("pls/challenge/late", nil)
+	submitLateMeter         = metrics.NewRegisteredMeter("pls/submit/late", nil)
+	rootchainEpochGauge     = metrics.NewRegisteredGauge("pls/rootchain/epoch", nil)
+	rootchainForkGauge      = metrics.NewRegisteredGauge("pls/rootchain/fork", nil)
 )
 
 type invalidExit struct {
@@ -43,6 +66,8 @@ type invalidExit struct {
 	receipt     *types.Receipt
 	index       int64
 	proof       []common.Hash
+	requestId   uint64
+	reason      string
 }
 
 type invalidExits []*invalidExit
@@ -54,29 +79,59 @@ type RootChainManager struct {
 	txPool     *core.TxPool
 	blockchain *core.BlockChain
 
-	backend           *ethclient.Client
+	backend           *failover.Client
 	rootchainContract *rootchain.RootChain
 
 	eventMux       *event.TypeMux
 	accountManager *accounts.Manager
 	txManager      *tx.TransactionManager
+	costs          *costLedger
+	powertonRounds *powertonRounds
 
 	miner    *miner.Miner
 	minerEnv *epoch.EpochEnvironment
 	state    *rootchainState
+	standby  *standby // nil if operator lease is not configured
 
 	// fork => block number => invalidExits
 	invalidExits map[uint64]map[uint64]invalidExits
+	// fork => block number => reverted requests which are not challenged
+	failedRequests map[uint64]map[uint64][]*failedRequest
 
 	// channels
-	quit             chan struct{}
-	epochPreparedCh  chan *rootchain.RootChainEpochPrepared
-	blockFinalizedCh chan *rootchain.RootChainBlockFinalized
+	quit chan struct{}
 
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 }
 
 func (rcm *RootChainManager) RootchainContract() *rootchain.RootChain { return rcm.rootchainContract }
+
+// BackendStatus returns the last health check result of the current root chain
+// provider.
+func (rcm *RootChainManager) BackendStatus() failover.Status {
+	return rcm.backend.Status()
+}
+
+// BackendStatuses returns the last health check results of all root chain
+// providers in the order of preference.
+func (rcm *RootChainManager) BackendStatuses() []failover.Status {
+	return rcm.backend.Statuses()
+}
+
+// CurrentFork returns the fork number followed by the miner environment. It is
+// updated by the root chain events, so no root chain call is made.
+func (rcm *RootChainManager) CurrentFork() uint64 {
+	rcm.minerEnv.Lock()
+	defer rcm.minerEnv.Unlock()
+
+	return rcm.minerEnv.CurrentFork.Uint64()
+}
+
+// PlasmaBlock returns the plasma block of the fork recorded in RootChain.
+func (rcm *RootChainManager) PlasmaBlock(forkNumber, number uint64) (rootchain.DataPlasmaBlock, error) {
+	return rcm.rootchainContract.GetBlock(baseCallOpt, new(big.Int).SetUint64(forkNumber), new(big.Int).SetUint64(number))
+}
+
 func (rcm *RootChainManager) NRELength() (*big.Int, error) {
 	return rcm.rootchainContract.NRELength(baseCallOpt)
 }
@@ -86,13 +141,15 @@ func NewRootChainManager(
 	stopFn func(),
 	txPool *core.TxPool,
 	blockchain *core.BlockChain,
-	backend *ethclient.Client,
+	backend *failover.Client,
 	rootchainContract *rootchain.RootChain,
 	eventMux *event.TypeMux,
 	accountManager *accounts.Manager,
 	txManager *tx.TransactionManager,
+	db ethdb.Database,
 	miner *miner.Miner,
 	env *epoch.EpochEnvironment,
+	journal *journalDB,
 ) (*RootChainManager, error) {
 	code, err := backend.CodeAt(context.Background(), config.RootChainContract, nil)
 	if err != nil {
@@ -126,16 +183,30 @@ func NewRootChainManager(
 		eventMux:          eventMux,
 		accountManager:    accountManager,
 		txManager:         txManager,
+		costs:             newCostLedger(db),
+		powertonRounds:    newPowerTONRounds(db),
 		miner:             miner,
 		minerEnv:          env,
 		invalidExits:      make(map[uint64]map[uint64]invalidExits),
+		failedRequests:    make(map[uint64]map[uint64][]*failedRequest),
 		quit:              make(chan struct{}),
-		epochPreparedCh:   make(chan *rootchain.RootChainEpochPrepared, MAX_EPOCH_EVENTS),
-		blockFinalizedCh:  make(chan *rootchain.RootChainBlockFinalized),
 	}
 
 	rcm.state = newRootchainState(rcm)
 
+	if config.OperatorLeaseFile != "" {
+		if config.NodeMode != ModeOperator {
+			return nil, errors.New("operator lease is only for operator node")
+		}
+		if journal == nil {
+			return nil, errors.New("operator lease requires the journal database")
+		}
+		rcm.standby = newStandby(rcm, journal)
+
+		// only the lease holder sends transactions to the root chain
+		txManager.SetSendGuard(rcm.standby.checkLease)
+	}
+
 	epochLength, err := rcm.NRELength()
 	if err != nil {
 		return nil, err
@@ -143,6 +214,11 @@ func NewRootChainManager(
 
 	if config.NodeMode == ModeOperator {
 		miner.SetNRBepochLength(epochLength)
+
+		if maxORBGas := rcm.state.maxORBGas(); config.Miner.GasFloor < maxORBGas {
+			log.Warn("Miner gas target is lower than request block capacity", "gasTarget", config.Miner.GasFloor,
+				"maxRequests", rcm.state.maxRequests, "requestGas", rcm.state.requestTxGasLimit(), "required", maxORBGas)
+		}
 	}
 
 	return rcm, nil
@@ -153,17 +229,36 @@ func (rcm *RootChainManager) Start() error {
 		return err
 	}
 
-	go rcm.pingBackend()
+	if rcm.standby != nil {
+		return rcm.standby.start()
+	}
+	rcm.activate()
+
+	return nil
+}
+
+// activate starts sending root chain transactions, and resumes mining if the
+// node is the operator.
+func (rcm *RootChainManager) activate() {
 	rcm.txManager.Start()
 
 	if rcm.config.NodeMode == ModeOperator {
 		go rcm.miner.Start(rcm.config.Operator.Address, new(rootchain.RootChainEpochPrepared), true)
+		go rcm.runSeigCommitter()
+		go rcm.runPowerTONKeeper()
 	}
+}
 
-	return nil
+// inStandby returns whether the operator is waiting for the lease held by
+// another node.
+func (rcm *RootChainManager) inStandby() bool {
+	return rcm.standby != nil && !rcm.standby.isActive()
 }
 
 func (rcm *RootChainManager) Stop() error {
+	if rcm.standby != nil {
+		rcm.standby.stop()
+	}
 	rcm.txManager.Stop()
 	rcm.backend.Close()
 	close(rcm.quit)
@@ -171,154 +266,41 @@ func (rcm *RootChainManager) Stop() error {
 }
 
 func (rcm *RootChainManager) run() error {
-	go rcm.runHandlers()
-	go rcm.runSubmitter()
-	go rcm.runDetector()
-
-	if err := rcm.watchEvents(); err != nil {
-		return err
+	// deadlines are estimated from the cached root chain head
+	if err := rcm.state.updateHead(); err != nil {
+		log.Warn("Failed to read root chain head", "err", err)
 	}
+	go rcm.state.trackHead(rcm.quit)
 
-	return nil
-}
-
-// watchEvents watchs RootChain contract events
-func (rcm *RootChainManager) watchEvents() error {
-	closed := false
+	go rcm.runSubmitter()
+	go rcm.runDetector()
+	go rcm.runCostLedger()
+	go rcm.runDeadlineMonitor()
+	go rcm.runPowerTONIndexer()
 
-	filterer, err := rootchain.NewRootChainFilterer(rcm.config.RootChainContract, rcm.backend)
+	start, err := scanStart(rcm.blockchain, rcm.blockchain.GetRootchainBlockNumber(), rcm.deploymentBlock)
 	if err != nil {
 		return err
 	}
+	scanner := newLogScanner(rcm.backend, rcm.blockchain, rcm.config.RootChainContract, start, rcm.handleRootChainLog)
 
-	startBlockNumber := rcm.blockchain.GetRootchainBlockNumber()
-	filterOpts := &bind.FilterOpts{
-		Start:   startBlockNumber,
-		End:     nil,
-		Context: context.Background(),
-	}
-
-	// TODO: have to read only NRE1
-	// iterate to find previous epoch prepared events
-	iteratorForEpochPreparedEvent, err := filterer.FilterEpochPrepared(filterOpts)
-	if err != nil {
+	// catch up with the past events before starting
+	if err := scanner.scan(rcm.quit); err != nil {
 		return err
 	}
+	go scanner.loop(rcm.quit)
 
-	log.Info("Iterating epoch prepared event")
-	for iteratorForEpochPreparedEvent.Next() {
-		e := iteratorForEpochPreparedEvent.Event
-		if e != nil {
-			if err := rcm.handleEpochPrepared(e); err != nil {
-				log.Error("Failed to handle past EpochPrepared events", "err", err)
-			}
-		}
-	}
-
-	// iterate to find previous block finalized events
-	iteratorForBlockFinalizedEvent, err := filterer.FilterBlockFinalized(filterOpts)
-	if err != nil {
-		return err
-	}
-
-	log.Info("Iterating block finalized event")
-	for iteratorForBlockFinalizedEvent.Next() {
-		e := iteratorForBlockFinalizedEvent.Event
-		if e != nil {
-			if err := rcm.handleBlockFinalized(e); err != nil {
-				log.Error("Failed to handle past BlockFinalized events", "err", err)
-			}
-		}
-	}
-
-	watchOpts := &bind.WatchOpts{
-		Context: context.Background(),
-		Start:   &startBlockNumber,
-	}
-	epochPrepareWatchCh := make(chan *rootchain.RootChainEpochPrepared)
-	blockFinalizedWatchCh := make(chan *rootchain.RootChainBlockFinalized)
-
-	log.Info("Watching epoch prepared event", "startBlockNumber", startBlockNumber)
-	epochPrepareSub, err := filterer.WatchEpochPrepared(watchOpts, epochPrepareWatchCh)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	log.Info("Watching block finalized event", "startBlockNumber", startBlockNumber)
-	blockFinalizedSub, err := filterer.WatchBlockFinalized(watchOpts, blockFinalizedWatchCh)
+// deploymentBlock returns the root chain block RootChain is deployed in. It is
+// found by the timestamp of the genesis epoch prepared in the deployment.
+func (rcm *RootChainManager) deploymentBlock() (uint64, error) {
+	genesis, err := rcm.rootchainContract.GetEpoch(baseCallOpt, big.NewInt(0), big.NewInt(0))
 	if err != nil {
-		return err
-	}
-
-	resubTimer := time.NewTimer(0)
-	<-resubTimer.C
-	resub := func() {
-		if closed {
-			return
-		}
-
-		startBlockNumber := rcm.blockchain.GetRootchainBlockNumber() + 1
-		watchOpts := &bind.WatchOpts{
-			Context: context.Background(),
-			Start:   &startBlockNumber,
-		}
-
-		log.Info("Re-subsribe EpochPrepared event", "startBlockNumber", startBlockNumber)
-		epochPrepareSub2, err := filterer.WatchEpochPrepared(watchOpts, epochPrepareWatchCh)
-		if err != nil {
-			log.Error("Failed to re-subscribe event", "err", err)
-			resubTimer.Reset(5 * time.Second)
-			return
-		}
-		epochPrepareSub = epochPrepareSub2
-
-		log.Info("Watching block finalized event", "startBlockNumber", startBlockNumber)
-		blockFinalizedSub2, err := filterer.WatchBlockFinalized(watchOpts, blockFinalizedWatchCh)
-		if err != nil {
-			log.Error("Failed to re-subscribe event", "err", err)
-			resubTimer.Reset(5 * time.Second)
-			return
-		}
-
-		blockFinalizedSub = blockFinalizedSub2
+		return 0, err
 	}
-
-	// TODO: wait untli previous submit transaction is mined.
-
-	go func() {
-		for {
-			select {
-			case e := <-epochPrepareWatchCh:
-				if e != nil {
-					rcm.epochPreparedCh <- e
-				}
-
-			case err := <-epochPrepareSub.Err():
-				if err != nil {
-					log.Error("Epoch prepared event subscription error", "err", err)
-					resub()
-				}
-
-			case e := <-blockFinalizedWatchCh:
-				if e != nil {
-					rcm.blockFinalizedCh <- e
-				}
-
-			case err := <-blockFinalizedSub.Err():
-				if err != nil {
-					log.Error("Block finalized event subscription error", "err", err)
-					resub()
-				}
-				return
-
-			case <-rcm.quit:
-				closed = true
-				return
-			}
-		}
-	}()
-
-	return nil
+	return firstBlockSince(rcm.backend, genesis.Timestamp)
 }
 
 func makePos(v1 *big.Int, v2 *big.Int) *big.Int {
@@ -354,6 +336,12 @@ func (rcm *RootChainManager) addEpochSubmitTransaction(blocks types.Blocks) erro
 	// pos2 = start block number * 2^128 + end block number
 	pos2 := makePos(startBlockNumber, endBlockNumber)
 
+	if rcm.standby != nil {
+		if err := rcm.standby.claim(forkNumber, epochNumber, endBlockNumber); err != nil {
+			return err
+		}
+	}
+
 	input, err := rootchainContractABI.Pack(
 		funcName,
 		pos1,
@@ -369,6 +357,9 @@ func (rcm *RootChainManager) addEpochSubmitTransaction(blocks types.Blocks) erro
 
 	caption := fmt.Sprintf("%s(%d: [%d-%d])", funcName, epochNumber.Uint64(), startBlockNumber.Uint64(), endBlockNumber.Uint64())
 	rawTx := tx.NewRawTransaction(operator.Address, params.SubmitBlockGasLimit, &rcm.config.RootChainContract, big.NewInt(int64(rcm.state.costNRB)), input, false, caption)
+	if rawTx.Deadline, err = rcm.state.deadlineAt(time.Now().Add(time.Duration(rcm.state.prepareTimeout) * time.Second)); err != nil {
+		log.Warn("Failed to estimate submission deadline, submit without deadline", "caption", caption, "err", err)
+	}
 
 	return rcm.txManager.Add(operator, rawTx, false)
 }
@@ -385,6 +376,12 @@ func (rcm *RootChainManager) addBlockSubmitTransaction(block *types.Block) error
 
 	pos := makePos(forkNumber, block.Number())
 
+	if rcm.standby != nil {
+		if err := rcm.standby.claim(forkNumber, rcm.minerEnv.EpochNumber, block.Number()); err != nil {
+			return err
+		}
+	}
+
 	input, err := rootchainContractABI.Pack(
 		funcName,
 		pos,
@@ -397,8 +394,11 @@ func (rcm *RootChainManager) addBlockSubmitTransaction(block *types.Block) error
 		return err
 	}
 
-	caption := fmt.Sprintf("%s(%d)", funcName, block.NumberU64())
+	caption := fmt.Sprintf("%s(%d: %d)", funcName, rcm.minerEnv.EpochNumber.Uint64(), block.NumberU64())
 	rawTx := tx.NewRawTransaction(operator.Address, params.SubmitBlockGasLimit, &rcm.config.RootChainContract, big.NewInt(int64(rcm.state.costNRB)), input, false, caption)
+	if rawTx.Deadline, err = rcm.state.deadlineAt(time.Now().Add(time.Duration(rcm.state.prepareTimeout) * time.Second)); err != nil {
+		log.Warn("Failed to estimate submission deadline, submit without deadline", "caption", caption, "err", err)
+	}
 
 	return rcm.txManager.Add(operator, rawTx, false)
 }
@@ -419,6 +419,8 @@ func (rcm *RootChainManager) runSubmitter() {
 		defer rcm.minerEnv.Unlock()
 
 		log.Info("New block is mined", "number", block.Number())
+		blockMinedMeter.Mark(1)
+		lastMinedBlockGauge.Update(block.Number().Int64())
 
 		// if the epoch is completed, stop mining operation and wait next epoch
 		if rcm.minerEnv.Completed {
@@ -454,13 +456,39 @@ func (rcm *RootChainManager) runSubmitter() {
 			return nil
 		}
 
+		// the request block is submitted by this or the previous operator in
+		// both cases, so the miner proceeds to the next request block
+		submittedORB := func() {
+			if err := rcm.miner.SubmittedORB(block.Number()); err != nil {
+				log.Warn("Failed to update request block state", "number", block.Number(), "err", err)
+			}
+		}
+
 		if err == tx.ErrDuplicateRaw {
 			log.Error("Same block submit transaction was included.")
+			if rcm.minerEnv.IsRequest {
+				submittedORB()
+			}
+			return nil
+		} else if err == errSubmissionClaimed {
+			log.Warn("Block is submitted by the previous operator", "number", block.Number())
+			if rcm.minerEnv.IsRequest {
+				submittedORB()
+			}
 			return nil
 		} else if err != nil {
 			return err
 		}
 
+		if rcm.minerEnv.IsRequest {
+			submittedORB()
+			blockSubmittedMeter.Mark(1)
+		} else {
+			blockSubmittedMeter.Mark(rcm.minerEnv.EndBlockNumber.Int64() - rcm.minerEnv.StartBlockNumber.Int64() + 1)
+		}
+		lastSubmittedBlockGauge.Update(block.Number().Int64())
+		submitDelayTimer.UpdateSince(time.Unix(int64(block.Time()), 0))
+
 		return nil
 	}
 
@@ -482,29 +510,67 @@ func (rcm *RootChainManager) runSubmitter() {
 	}
 }
 
-func (rcm *RootChainManager) runHandlers() {
-	if rcm.config.NodeMode != ModeOperator {
-		return
+// retryableError is an error of a root chain call made while handling an
+// event. The event is fed again if its handler fails with it, while the events
+// failing with other errors are skipped.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string { return e.err.Error() }
+
+// handleRootChainLog handles the RootChain log fed by the log scanner. Logs are
+// fed in the order of the root chain, and fed again after a reorg or a failed
+// root chain call, so the handlers skip the events already applied.
+func (rcm *RootChainManager) handleRootChainLog(l types.Log) error {
+	if len(l.Topics) == 0 {
+		return nil
 	}
 
-	for {
-		select {
-		case e := <-rcm.epochPreparedCh:
-			if err := rcm.handleEpochPrepared(e); err != nil {
-				log.Error("Failed to handle epoch prepared", "err", err)
-			} else {
-				rcm.blockchain.SetRootchainBlockNumber(e.Raw.BlockNumber)
-			}
-		case e := <-rcm.blockFinalizedCh:
-			if err := rcm.handleBlockFinalized(e); err != nil {
-				log.Error("Failed to handle block finazlied", "err", err)
-			} else {
-				rcm.blockchain.SetRootchainBlockNumber(e.Raw.BlockNumber)
-			}
-		case <-rcm.quit:
-			return
+	var (
+		name string
+		err  error
+	)
+	switch l.Topics[0] {
+	case epochPreparedEventID:
+		name = "EpochPrepared"
+		var e *rootchain.RootChainEpochPrepared
+		if e, err = rcm.rootchainContract.ParseEpochPrepared(l); err == nil {
+			err = rcm.handleEpochPrepared(e)
+		}
+	case blockFinalizedEventID:
+		name = "BlockFinalized"
+		var e *rootchain.RootChainBlockFinalized
+		if e, err = rcm.rootchainContract.ParseBlockFinalized(l); err == nil {
+			err = rcm.handleBlockFinalized(e)
 		}
+	case forkedEventID:
+		name = "Forked"
+		var e *rootchain.RootChainForked
+		if e, err = rcm.rootchainContract.ParseForked(l); err == nil {
+			err = rcm.handleForked(e)
+		}
+	default:
+		return nil
 	}
+
+	if _, ok := err.(retryableError); ok {
+		return fmt.Errorf("failed to handle %s: %v", name, err)
+	} else if err != nil {
+		log.Error("Failed to handle root chain event, skip it", "event", name, "block", l.BlockNumber, "tx", l.TxHash, "err", err)
+	}
+
+	rcm.blockchain.SetRootchainBlockNumber(l.BlockNumber)
+	return nil
+}
+
+// epochApplied returns whether the epoch of the fork is not after the current
+// epoch, i.e. its EpochPrepared event is already handled.
+func epochApplied(currentFork, currentEpoch, fork, epoch *big.Int) bool {
+	if c := fork.Cmp(currentFork); c != 0 {
+		return c < 0
+	}
+	return epoch.Cmp(currentEpoch) <= 0
 }
 
 // handleEpochPrepared handles EpochPrepared event from RootChain contract after
@@ -518,13 +584,18 @@ func (rcm *RootChainManager) handleEpochPrepared(ev *rootchain.RootChainEpochPre
 		return errors.New(fmt.Sprintf("EpochPrepared#%s event is removed. root chain would had been reorganized.", ev.EpochNumber.String()))
 	}
 
-	// Short circuit if epoch prepared event is fired due to reorg.
-	if rcm.minerEnv.EpochNumber.Cmp(ev.EpochNumber) >= 0 {
-		return errors.New(fmt.Sprintf("Epoch#%s is less than current epoch#%s.", ev.EpochNumber.String(), rcm.minerEnv.EpochNumber.String()))
+	// Short circuit if epoch prepared event is fed again.
+	if epochApplied(rcm.minerEnv.CurrentFork, rcm.minerEnv.EpochNumber, ev.ForkNumber, ev.EpochNumber) {
+		log.Debug("Epoch is already prepared", "forkNumber", ev.ForkNumber, "epochNumber", ev.EpochNumber,
+			"currentFork", rcm.minerEnv.CurrentFork, "currentEpoch", rcm.minerEnv.EpochNumber)
+		return nil
 	}
 
 	e := *ev
 
+	rootchainEpochGauge.Update(e.EpochNumber.Int64())
+	rootchainForkGauge.Update(e.ForkNumber.Int64())
+
 	length := new(big.Int).Add(new(big.Int).Sub(e.EndBlockNumber, e.StartBlockNumber), big.NewInt(1))
 
 	log.Info("RootChain epoch prepared",
@@ -544,162 +615,121 @@ func (rcm *RootChainManager) handleEpochPrepared(ev *rootchain.RootChainEpochPre
 		return nil
 	}
 
-	if rcm.config.NodeMode == ModeOperator {
-		go rcm.miner.Start(rcm.config.Operator.Address, &e, false)
+	// TODO: handle ModeUser
+	if rcm.config.NodeMode != ModeOperator {
+		return nil
 	}
 
-	// prepare request tx for ORBs
-	if e.IsRequest && !e.EpochIsEmpty {
-		events := rcm.eventMux.Subscribe(core.NewMinedBlockEvent{})
-		defer events.Unsubscribe()
-
-		numORBs := new(big.Int).Sub(e.EndBlockNumber, e.StartBlockNumber)
-		numORBs = new(big.Int).Add(numORBs, big.NewInt(1))
-
-		bodies := make([]types.Transactions, 0, numORBs.Uint64()) // [][]types.Transaction
+	// the epoch environment of the standby is replicated from the primary
+	if rcm.inStandby() {
+		return nil
+	}
 
+	// read the requests before the epoch is started, so the event is handled
+	// again if it fails
+	var bodies []types.Transactions
+	if e.IsRequest && !e.EpochIsEmpty {
 		currentFork := big.NewInt(int64(rcm.state.currentFork))
-		epoch, err := rcm.getEpoch(currentFork, e.EpochNumber)
-		if err != nil {
-			return err
+		var err error
+		if bodies, err = plasma.RequestBlockBodies(rcm.rootchainContract, baseCallOpt, currentFork, e.EpochNumber, rcm.state.requestGas); err != nil {
+			return retryableError{err}
 		}
-		log.Debug("rcm.getEpoch", "epoch", epoch)
+	}
 
-		// TODO: URE, ORE' should handle requestBlockId in a different way.
-		requestBlockId := big.NewInt(int64(epoch.RE.FirstRequestBlockId))
+	// request blocks of the previous fork are never submitted
+	if e.Rebase || e.ForkNumber.Cmp(rcm.minerEnv.CurrentFork) != 0 {
+		rcm.miner.CancelORBs("fork")
+	}
 
-		log.Debug("Num Orbs", "epochNumber", e.EpochNumber, "numORBs", numORBs, "requestBlockId", requestBlockId, "e.EndBlockNumber", e.EndBlockNumber, "e.StartBlockNumber", e.StartBlockNumber)
-		for blockNumber := e.StartBlockNumber; blockNumber.Cmp(e.EndBlockNumber) <= 0; {
-			begin := time.Now()
+	go rcm.miner.Start(rcm.config.Operator.Address, &e, false)
 
-			orb, err := rcm.rootchainContract.ORBs(baseCallOpt, requestBlockId)
-			if err != nil {
-				return err
+	// prepare request tx for ORBs
+	if e.IsRequest && !e.EpochIsEmpty {
+		// every request must fit in the request block
+		maxORBGas, gasLimit := rcm.state.maxORBGas(), rcm.blockchain.CurrentBlock().GasLimit()
+		for i, body := range bodies {
+			bodyGas := uint64(0)
+			for _, requestTx := range body {
+				bodyGas += requestTx.Gas()
 			}
-
-			numRequests := orb.RequestEnd - orb.RequestStart + 1
-			log.Debug("Fetching ORB", "blockNumber", blockNumber, "requestStart", orb.RequestStart, "requestEnd", orb.RequestEnd, "requestBlockId", requestBlockId)
-
-			body := make(types.Transactions, 0, numRequests)
-
-			for requestId := orb.RequestStart; requestId <= orb.RequestEnd; {
-				request, err := rcm.rootchainContract.EROs(baseCallOpt, big.NewInt(int64(requestId)))
-				if err != nil {
-					return err
-				}
-
-				log.Debug("Request fetched", "requestId", requestId, "hash", common.Bytes2Hex(request.Hash[:]), "request", request)
-
-				var (
-					to    common.Address
-					value *big.Int
-					input []byte
-				)
-
-				if request.IsTransfer && !request.IsExit {
-					to = request.Requestor
-					value = new(big.Int).SetBytes(request.TrieValue[:])
-				} else {
-					to, _ = rcm.rootchainContract.RequestableContracts(baseCallOpt, request.To)
-					value = request.Value
-					input, err = requestableContractABI.Pack("applyRequestInChildChain",
-						request.IsExit,
-						big.NewInt(int64(requestId)),
-						request.Requestor,
-						request.TrieKey,
-						request.TrieValue,
-					)
-					if err != nil {
-						log.Error("Failed to pack applyRequestInChildChain", "err", err)
-					}
-
-					log.Debug("Request tx.data", "payload", common.Bytes2Hex(input))
-				}
-
-				requestTx := types.NewTransaction(0, to, value, params.RequestTxGasLimit, params.RequestTxGasPrice, input)
-
-				log.Debug("Request Transaction", "tx", requestTx)
-
-				body = append(body, requestTx)
-				requestId += 1
+			if bodyGas > maxORBGas || bodyGas > gasLimit {
+				log.Error("Request block gas exceeded", "blockNumber", new(big.Int).Add(e.StartBlockNumber, big.NewInt(int64(i))),
+					"gas", bodyGas, "maxORBGas", maxORBGas, "gasLimit", gasLimit)
+				return errORBGasExceeded
 			}
-
-			log.Info("Request txs fetched", "blockNumber", blockNumber, "requestBlockId", requestBlockId, "numRequests", len(body), "elapsed", time.Since(begin))
-
-			bodies = append(bodies, body)
-
-			blockNumber = new(big.Int).Add(blockNumber, big.NewInt(1))
-			requestBlockId = new(big.Int).Add(requestBlockId, big.NewInt(1))
 		}
 
-		var numMinedORBs uint64 = 0
-
-		// TODO: handle ModeUser
-		if rcm.config.NodeMode != ModeOperator {
-			return nil
+		if err := rcm.miner.EnqueueORBs(e.ForkNumber, e.EpochNumber, e.StartBlockNumber, bodies); err != nil {
+			return err
 		}
+	}
 
-		// Unlock mutex and make submit loop to process
-		rcm.lock.Unlock()
-		for numMinedORBs < numORBs.Uint64() {
-			if err := rcm.txPool.EnqueueReqeustTxs(bodies[numMinedORBs]); err != nil {
-				return err
-			}
-
-			log.Info("Waiting new request block mined event...")
-
-			e := <-events.Chan()
-			block := e.Data.(core.NewMinedBlockEvent).Block
-
-			log.Info("New request block is mined", "blockNumber", block.Number(), "txs", block.Transactions().Len())
+	return nil
+}
 
-			if !block.IsRequest() {
-				return errors.New("Invalid request block type.")
-			}
+// handleForked cancels the pending NRE submissions of the previous fork. The
+// root chain rejects them after the fork, and they would block the following
+// transactions of the operator.
+func (rcm *RootChainManager) handleForked(ev *rootchain.RootChainForked) error {
+	if rcm.config.NodeMode != ModeOperator || rcm.inStandby() {
+		return nil
+	}
 
-			receipts := rcm.blockchain.GetReceiptsByHash(block.Hash())
+	// the fork is already handled if the logs are scanned again
+	if ev.NewFork.Uint64() <= rcm.CurrentFork() {
+		return nil
+	}
 
-			for _, receipt := range receipts {
-				if receipt.Status == 0 {
-					log.Error("Request transaction is reverted", "blockNumber", block.Number(), "hash", receipt.TxHash)
-				}
-			}
+	log.Info("RootChain forked", "newFork", ev.NewFork, "epochNumber", ev.EpochNumber, "forkedBlockNumber", ev.ForkedBlockNumber)
 
-			numMinedORBs += 1
+	for _, raw := range rcm.txManager.PendingRaws(rcm.config.Operator.Address) {
+		if kind, _, _, _ := parseSubmitCaption(raw.Caption); kind != "submitNRE" {
+			continue
 		}
-
-		// Re-lock
-		rcm.lock.Lock()
+		if err := rcm.txManager.Cancel(raw); err == tx.ErrMinedRaw {
+			continue
+		} else if err != nil {
+			return err
+		}
+		log.Info("Submission of the previous fork is cancelled", "caption", raw.Caption, "nonce", raw.Nonce)
 	}
-
 	return nil
 }
 
 // Challenge on invalid exits
 func (rcm *RootChainManager) handleBlockFinalized(ev *rootchain.RootChainBlockFinalized) error {
+	// TODO: handle ModeUser
+	if rcm.config.NodeMode != ModeOperator {
+		return nil
+	}
+
 	rcm.lock.Lock()
 	defer rcm.lock.Unlock()
 
 	e := *ev
 
 	log.Info("RootChain block finalized", "forkNumber", e.ForkNumber, "blockNubmer", e.BlockNumber)
+	blockFinalizedMeter.Mark(1)
+	lastFinalizedBlockGauge.Update(e.BlockNumber.Int64())
 
 	callerOpts := &bind.CallOpts{
 		Pending: true,
 		Context: context.Background(),
 	}
 
-	w, err := rcm.accountManager.Find(rcm.config.Challenger)
-	if err != nil {
-		log.Error("Failed to get challenger account", "err", err)
-	}
-
 	block, err := rcm.rootchainContract.GetBlock(callerOpts, e.ForkNumber, e.BlockNumber)
 	if err != nil {
-		return err
+		return retryableError{err}
 	}
 
-	if block.IsRequest {
+	// the primary challenges invalid exits
+	if block.IsRequest && !rcm.inStandby() {
+		// invalid exits must be challenged before the exit challenge period ends
+		deadline, err := rcm.state.deadlineAt(time.Unix(int64(block.FinalizedAt+rcm.state.cpExit), 0))
+		if err != nil {
+			log.Warn("Failed to estimate challenge deadline, challenge without deadline", "blockNumber", e.BlockNumber, "err", err)
+		}
+
 		invalidExits := rcm.invalidExits[e.ForkNumber.Uint64()][e.BlockNumber.Uint64()]
 		for i := 0; i < len(invalidExits); i++ {
 
@@ -712,26 +742,21 @@ func (rcm *RootChainManager) handleBlockFinalized(ev *rootchain.RootChainBlockFi
 			input, err := rootchainContractABI.Pack("challengeExit", e.ForkNumber, e.BlockNumber, big.NewInt(invalidExits[i].index), invalidExits[i].receipt.GetRlp(), proofs)
 			if err != nil {
 				log.Error("Failed to pack challengeExit", "err", err)
+				continue
 			}
 
-			nonce, err := rcm.backend.NonceAt(context.Background(), rcm.config.Challenger.Address, nil)
-			if err != nil {
-				log.Error("Failed to get challenger nonce", "err", err)
-			}
-
-			// TODO: use tx.TransactionManager
-			challengeTx := types.NewTransaction(uint64(nonce), rcm.config.RootChainContract, big.NewInt(0), params.SubmitBlockGasLimit, params.SubmitBlockGasPrice, input)
-
-			signedTx, err := w.SignTx(rcm.config.Challenger, challengeTx, big.NewInt(int64(rcm.config.RootChainNetworkID)))
-			if err != nil {
-				log.Error("Failed to sign challengeTx", "err", err)
-			}
+			caption := fmt.Sprintf("challengeExit(%d: %d)", e.BlockNumber.Uint64(), invalidExits[i].index)
+			rawTx := tx.NewRawTransaction(rcm.config.Challenger.Address, params.SubmitBlockGasLimit, &rcm.config.RootChainContract, big.NewInt(0), input, false, caption)
+			rawTx.Deadline = deadline
 
-			err = rcm.backend.SendTransaction(context.Background(), signedTx)
-			if err != nil {
-				log.Error("Failed to send challengeTx", "err", err)
+			if err := rcm.txManager.Add(rcm.config.Challenger, rawTx, false); err == tx.ErrDuplicateRaw {
+				log.Debug("challengeExit is already added", "exit request number", invalidExits[i].index)
+			} else if err != nil {
+				log.Error("Failed to add challengeExit", "err", err)
+				challengeFailedMeter.Mark(1)
 			} else {
-				log.Info("challengeExit is submitted", "exit request number", invalidExits[i].index, "hash", signedTx.Hash().Hex())
+				challengeSentMeter.Mark(1)
+				log.Info("challengeExit is added", "exit request number", invalidExits[i].index, "deadline", deadline)
 			}
 		}
 	}
@@ -739,6 +764,39 @@ func (rcm *RootChainManager) handleBlockFinalized(ev *rootchain.RootChainBlockFi
 	return nil
 }
 
+// runDeadlineMonitor alerts the consequences of the root chain transactions
+// which are not mined by their deadlines.
+func (rcm *RootChainManager) runDeadlineMonitor() {
+	missedCh := make(chan tx.DeadlineMissedEvent, 16)
+	sub := rcm.txManager.SubscribeDeadlineMissed(missedCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-missedCh:
+			raw := ev.Raw
+			switch kind, epoch, start, end := parseSubmitCaption(raw.Caption); kind {
+			case "submitNRE", "submitORB":
+				submitLateMeter.Mark(1)
+				log.Error("Submission missed the prepare timeout, users can activate the user request epoch", "epoch", epoch,
+					"startBlock", start, "endBlock", end, "deadline", raw.Deadline, "rootchainBlock", ev.BlockNumber)
+			case "challengeExit":
+				challengeLateMeter.Mark(1)
+				log.Error("Challenge missed the exit challenge period, the invalid exit may be finalized", "caption", raw.Caption,
+					"deadline", raw.Deadline, "rootchainBlock", ev.BlockNumber)
+			default:
+				log.Error("Root chain transaction missed its deadline", "caption", raw.Caption,
+					"deadline", raw.Deadline, "rootchainBlock", ev.BlockNumber)
+			}
+
+		case <-sub.Err():
+			return
+		case <-rcm.quit:
+			return
+		}
+	}
+}
+
 func (rcm *RootChainManager) runDetector() {
 	if rcm.config.NodeMode == ModeUser {
 		return
@@ -758,45 +816,71 @@ func (rcm *RootChainManager) runDetector() {
 			if !ok {
 				continue
 			}
-			rcm.lock.Lock()
-
 			block := ev.Data.(core.NewMinedBlockEvent).Block
+			if !block.IsRequest() {
+				continue
+			}
+
+			// the root chain is read without the lock, which is taken only to
+			// record the result
+			forkNumber, err := rcm.rootchainContract.CurrentFork(callerOpts)
+			if err != nil {
+				log.Warn("failed to get current fork number", "err", err)
+				continue
+			}
+			var rootchainBlock rootchain.DataPlasmaBlock
+			blockErr := retryLookup(func() (err error) {
+				rootchainBlock, err = rcm.rootchainContract.GetBlock(callerOpts, forkNumber, block.Number())
+				return err
+			})
 
-			if block.IsRequest() {
-				var invalidExitsList invalidExits
+			receipts := rcm.blockchain.GetReceiptsByHash(block.Hash())
 
-				forkNumber, err := rcm.rootchainContract.CurrentFork(callerOpts)
-				if err != nil {
-					log.Warn("failed to get current fork number", "err", err)
-					rcm.lock.Unlock()
+			// only exit requests are challenged, reverted enter and undo requests are just recorded.
+			var (
+				invalidExitsList invalidExits
+				failedRequests   []*failedRequest
+			)
+			for i := 0; i < len(receipts); i++ {
+				if receipts[i].Status != types.ReceiptStatusFailed {
 					continue
 				}
 
-				// TODO: read and write to DB
-				if rcm.invalidExits[forkNumber.Uint64()] == nil {
-					rcm.invalidExits[forkNumber.Uint64()] = make(map[uint64]invalidExits)
+				var (
+					invalidExit *invalidExit
+					failed      *failedRequest
+				)
+				if blockErr != nil {
+					// without the request block, requests are not known to be EROs or ERUs
+					invalidExit, failed = classifyFailedRequest(forkNumber, block, receipts, i, 0, requestExit, blockErr)
+				} else {
+					invalidExit, failed = rcm.checkFailedRequest(callerOpts, forkNumber, rootchainBlock.UserActivated, block, receipts, i)
 				}
+				if failed != nil {
+					failedRequests = append(failedRequests, failed)
+					failedRequestMeter.Mark(1)
 
-				receipts := rcm.blockchain.GetReceiptsByHash(block.Hash())
-
-				// TODO: should check if the request[i] is enter or exit request. Undo request will make posterior enter request.
-				for i := 0; i < len(receipts); i++ {
-					if receipts[i].Status == types.ReceiptStatusFailed {
-						invalidExit := &invalidExit{
-							forkNumber:  forkNumber,
-							blockNumber: block.Number(),
-							receipt:     receipts[i],
-							index:       int64(i),
-							proof:       types.GetMerkleProof(receipts, i),
-						}
-						invalidExitsList = append(invalidExitsList, invalidExit)
-
-						log.Info("Invalid Exit Detected", "invalidExit", invalidExit, "forkNumber", forkNumber, "blockNumber", block.Number())
-					}
+					log.Warn("Failed request is not challenged", "forkNumber", forkNumber, "blockNumber", block.Number(), "index", i,
+						"requestId", failed.requestId, "kind", failed.kind, "reason", failed.reason)
+					continue
 				}
-				// TODO: read and write to DB
-				rcm.invalidExits[forkNumber.Uint64()][block.NumberU64()] = invalidExitsList
+				invalidExitsList = append(invalidExitsList, invalidExit)
+				invalidExitMeter.Mark(1)
+
+				log.Info("Invalid Exit Detected", "invalidExit", invalidExit, "forkNumber", forkNumber, "blockNumber", block.Number(),
+					"requestId", invalidExit.requestId, "reason", invalidExit.reason)
+			}
+
+			rcm.lock.Lock()
+			// TODO: read and write to DB
+			if rcm.invalidExits[forkNumber.Uint64()] == nil {
+				rcm.invalidExits[forkNumber.Uint64()] = make(map[uint64]invalidExits)
+			}
+			if rcm.failedRequests[forkNumber.Uint64()] == nil {
+				rcm.failedRequests[forkNumber.Uint64()] = make(map[uint64][]*failedRequest)
 			}
+			rcm.failedRequests[forkNumber.Uint64()][block.NumberU64()] = failedRequests
+			rcm.invalidExits[forkNumber.Uint64()][block.NumberU64()] = invalidExitsList
 			rcm.lock.Unlock()
 
 		case <-rcm.quit:
@@ -820,20 +904,3 @@ func (rcm *RootChainManager) lastBlock(forkNumber *big.Int, pending bool) (*big.
 	}
 	return num, nil
 }
-
-// pingBackend checks rootchain backend is alive.
-func (rcm *RootChainManager) pingBackend() {
-	ticker := time.NewTicker(3 * time.Second)
-
-	for {
-		select {
-		case <-ticker.C:
-			if _, err := rcm.backend.SyncProgress(context.Background()); err != nil {
-				log.Error("Rootchain provider doesn't respond", "err", err)
-			}
-		case <-rcm.quit:
-			ticker.Stop()
-			return
-		}
-	}
-}
@@ -0,0 +1,264 @@
+package pls
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/metrics"
+)
+
+const (
+	scanInterval   = 3 * time.Second // interval between polls of the root chain head
+	scanBlockRange = 1000            // maximum number of root chain blocks filtered at once
+	maxReorgDepth  = 64              // root chain blocks rewound if no checkpoint survives a reorg
+	maxCheckpoints = 128             // recent checkpoints kept to find the common ancestor
+)
+
+var errScanReorged = errors.New("root chain reorganized while scanning")
+
+var (
+	scannedBlockGauge   = metrics.NewRegisteredGauge("pls/rootchain/scanned", nil)
+	scannedLogMeter     = metrics.NewRegisteredMeter("pls/rootchain/logs", nil)
+	rootchainReorgMeter = metrics.NewRegisteredMeter("pls/rootchain/reorg", nil)
+)
+
+// scanBackend is the root chain backend the log scanner polls.
+type scanBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// checkpointStore persists the last root chain block whose logs are processed.
+type checkpointStore interface {
+	GetRootchainCheckpoint() (uint64, common.Hash, bool)
+	SetRootchainCheckpoint(number uint64, hash common.Hash)
+}
+
+type scanCheckpoint struct {
+	number uint64
+	hash   common.Hash
+}
+
+// logScanner walks the root chain in block ranges and feeds the logs of the
+// contract to the handler in order. Only plain JSONRPC calls are used, so it
+// works over HTTP providers too.
+//
+// The last block whose logs are all handled is checkpointed in the database.
+// If the handler fails, the scanner stops without checkpointing and feeds the
+// logs of the range again on the next scan. If the checkpoint is reorganized, the scanner rewinds to the latest recent
+// checkpoint still in the canonical chain and feeds the logs from there again,
+// so handlers must tolerate logs they have already seen.
+type logScanner struct {
+	backend scanBackend
+	store   checkpointStore
+	address common.Address
+	handler func(types.Log) error
+
+	next        uint64           // first root chain block not scanned
+	checkpoints []scanCheckpoint // recent checkpoints, the latest one is the last
+}
+
+// newLogScanner creates a scanner resuming from the stored checkpoint, or
+// starting from the given block if there is none.
+func newLogScanner(backend scanBackend, store checkpointStore, address common.Address, start uint64, handler func(types.Log) error) *logScanner {
+	s := &logScanner{
+		backend: backend,
+		store:   store,
+		address: address,
+		handler: handler,
+		next:    start,
+	}
+	if number, hash, ok := store.GetRootchainCheckpoint(); ok {
+		s.next = number + 1
+		s.checkpoints = []scanCheckpoint{{number, hash}}
+	}
+	return s
+}
+
+// scanStart returns the root chain block a scanner without checkpoint starts
+// from. A node upgraded from the event subscription resumes after the root chain
+// block of the last handled event, and a new node starts from the block
+// RootChain is deployed in.
+func scanStart(store checkpointStore, rootchainBlock uint64, deployment func() (uint64, error)) (uint64, error) {
+	if _, _, ok := store.GetRootchainCheckpoint(); ok {
+		return 0, nil
+	}
+	if rootchainBlock != 0 {
+		return rootchainBlock + 1, nil
+	}
+	return deployment()
+}
+
+// loop scans the root chain until quit is closed.
+func (s *logScanner) loop(quit chan struct{}) {
+	log.Info("Scanning root chain events", "contract", s.address, "from", s.next)
+
+	ticker := time.NewTicker(scanInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.scan(quit); err != nil {
+			log.Warn("Failed to scan root chain events", "next", s.next, "err", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-quit:
+			return
+		}
+	}
+}
+
+// scan feeds the logs up to the current root chain head.
+func (s *logScanner) scan(quit chan struct{}) error {
+	if err := s.rewind(); err != nil {
+		return err
+	}
+
+	head, err := s.backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	for s.next <= head.Number.Uint64() {
+		select {
+		case <-quit:
+			return nil
+		default:
+		}
+
+		to := s.next + scanBlockRange - 1
+		if to > head.Number.Uint64() {
+			to = head.Number.Uint64()
+		}
+		if err := s.scanRange(s.next, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanRange feeds the logs in the blocks [from, to] and checkpoints the last
+// block.
+func (s *logScanner) scanRange(from, to uint64) error {
+	last, err := s.backend.HeaderByNumber(context.Background(), new(big.Int).SetUint64(to))
+	if err != nil {
+		return err
+	}
+
+	logs, err := s.backend.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{s.address},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the logs and the checkpoint must be of the same chain
+	if header, err := s.backend.HeaderByNumber(context.Background(), last.Number); err != nil {
+		return err
+	} else if header.Hash() != last.Hash() {
+		return errScanReorged
+	}
+
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		if err := s.handler(l); err != nil {
+			log.Error("Failed to handle root chain event", "block", l.BlockNumber, "tx", l.TxHash, "err", err)
+			return err
+		}
+	}
+	scannedLogMeter.Mark(int64(len(logs)))
+
+	s.checkpoint(to, last.Hash())
+	return nil
+}
+
+func (s *logScanner) checkpoint(number uint64, hash common.Hash) {
+	s.checkpoints = append(s.checkpoints, scanCheckpoint{number, hash})
+	if len(s.checkpoints) > maxCheckpoints {
+		s.checkpoints = s.checkpoints[len(s.checkpoints)-maxCheckpoints:]
+	}
+	s.store.SetRootchainCheckpoint(number, hash)
+	s.next = number + 1
+
+	scannedBlockGauge.Update(int64(number))
+}
+
+// rewind moves the scanner back to the latest checkpoint in the canonical
+// chain if the root chain is reorganized.
+func (s *logScanner) rewind() error {
+	var dropped *scanCheckpoint
+
+	for len(s.checkpoints) > 0 {
+		cp := s.checkpoints[len(s.checkpoints)-1]
+
+		header, err := s.backend.HeaderByNumber(context.Background(), new(big.Int).SetUint64(cp.number))
+		if err != nil && err != ethereum.NotFound {
+			return err
+		}
+		if header != nil && header.Hash() == cp.hash {
+			break
+		}
+		dropped = &cp
+		s.checkpoints = s.checkpoints[:len(s.checkpoints)-1]
+	}
+
+	if dropped == nil {
+		return nil
+	}
+	rootchainReorgMeter.Mark(1)
+
+	if len(s.checkpoints) > 0 {
+		cp := s.checkpoints[len(s.checkpoints)-1]
+		log.Warn("Root chain reorganized, rescanning events", "from", cp.number+1, "dropped", dropped.number)
+
+		s.store.SetRootchainCheckpoint(cp.number, cp.hash)
+		s.next = cp.number + 1
+		return nil
+	}
+
+	// no checkpoint left to find the common ancestor
+	next := uint64(0)
+	if dropped.number > maxReorgDepth {
+		next = dropped.number - maxReorgDepth
+	}
+	log.Warn("Root chain reorganized beyond checkpoints, rescanning events", "from", next, "dropped", dropped.number)
+
+	s.next = next
+	return nil
+}
+
+// firstBlockSince returns the first root chain block whose timestamp is not
+// before the given time.
+func firstBlockSince(backend scanBackend, timestamp uint64) (uint64, error) {
+	head, err := backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	lo, hi := uint64(0), head.Number.Uint64()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		header, err := backend.HeaderByNumber(context.Background(), new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, err
+		}
+		if header.Time < timestamp {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
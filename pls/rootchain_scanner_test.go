@@ -0,0 +1,245 @@
+package pls
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+)
+
+// testScanChain is a root chain with a log in every block.
+type testScanChain struct {
+	headers []*types.Header
+}
+
+func (c *testScanChain) extend(n int, fork byte) {
+	for i := 0; i < n; i++ {
+		header := &types.Header{Number: big.NewInt(int64(len(c.headers))), Time: uint64(len(c.headers)) * 10, Extra: []byte{fork}}
+		if len(c.headers) > 0 {
+			header.ParentHash = c.headers[len(c.headers)-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+func (c *testScanChain) reorg(number uint64, fork byte) {
+	n := len(c.headers) - int(number)
+	c.headers = c.headers[:number]
+	c.extend(n, fork)
+}
+
+func (c *testScanChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *testScanChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64() && n < uint64(len(c.headers)); n++ {
+		logs = append(logs, types.Log{Address: q.Addresses[0], BlockNumber: n, BlockHash: c.headers[n].Hash()})
+	}
+	return logs, nil
+}
+
+type testCheckpointStore struct {
+	number uint64
+	hash   common.Hash
+	ok     bool
+}
+
+func (s *testCheckpointStore) GetRootchainCheckpoint() (uint64, common.Hash, bool) {
+	return s.number, s.hash, s.ok
+}
+
+func (s *testCheckpointStore) SetRootchainCheckpoint(number uint64, hash common.Hash) {
+	s.number, s.hash, s.ok = number, hash, true
+}
+
+func TestLogScanner(t *testing.T) {
+	chain := new(testScanChain)
+	chain.extend(scanBlockRange+10, 0)
+
+	store := new(testCheckpointStore)
+	quit := make(chan struct{})
+
+	var fed []types.Log
+	handler := func(l types.Log) error {
+		fed = append(fed, l)
+		return nil
+	}
+
+	scanner := newLogScanner(chain, store, common.Address{}, 0, handler)
+	if err := scanner.scan(quit); err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if len(fed) != len(chain.headers) {
+		t.Fatalf("fed logs mismatch: have %d, want %d", len(fed), len(chain.headers))
+	}
+	for i, l := range fed {
+		if l.BlockNumber != uint64(i) {
+			t.Fatalf("log %d out of order: block %d", i, l.BlockNumber)
+		}
+	}
+	if head := chain.headers[len(chain.headers)-1]; store.number != head.Number.Uint64() || store.hash != head.Hash() {
+		t.Fatalf("checkpoint mismatch: have %d, want %d", store.number, head.Number)
+	}
+
+	// new blocks on top of the checkpoint
+	chain.extend(5, 0)
+	fed = nil
+	if err := scanner.scan(quit); err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if len(fed) != 5 || fed[0].BlockNumber != scanBlockRange+10 {
+		t.Fatalf("fed logs mismatch after new blocks: %d logs", len(fed))
+	}
+
+	// blocks since the first checkpoint are reorganized
+	chain.reorg(scanBlockRange, 1)
+	fed = nil
+	if err := scanner.scan(quit); err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if len(fed) != 15 || fed[0].BlockNumber != scanBlockRange || fed[0].BlockHash != chain.headers[scanBlockRange].Hash() {
+		t.Fatalf("fed logs mismatch after reorg: %d logs", len(fed))
+	}
+
+	// a new scanner resumes from the checkpoint
+	chain.extend(1, 1)
+	fed = nil
+	if err := newLogScanner(chain, store, common.Address{}, 0, handler).scan(quit); err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if len(fed) != 1 || fed[0].BlockNumber != uint64(len(chain.headers)-1) {
+		t.Fatalf("fed logs mismatch after restart: %d logs", len(fed))
+	}
+}
+
+// Tests that the range of a log the handler fails on is fed again without
+// checkpointing.
+func TestLogScannerHandlerError(t *testing.T) {
+	chain := new(testScanChain)
+	chain.extend(10, 0)
+
+	store := new(testCheckpointStore)
+	quit := make(chan struct{})
+
+	var (
+		fed     []types.Log
+		failing = true
+	)
+	handler := func(l types.Log) error {
+		if l.BlockNumber == 5 && failing {
+			return errors.New("failed")
+		}
+		fed = append(fed, l)
+		return nil
+	}
+
+	scanner := newLogScanner(chain, store, common.Address{}, 0, handler)
+	if err := scanner.scan(quit); err == nil {
+		t.Fatalf("scan error mismatch: have nil, want error")
+	}
+	if store.ok || scanner.next != 0 {
+		t.Fatalf("checkpoint advanced: have %d, next %d", store.number, scanner.next)
+	}
+
+	failing, fed = false, nil
+	if err := scanner.scan(quit); err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if len(fed) != len(chain.headers) || store.number != uint64(len(chain.headers)-1) {
+		t.Fatalf("fed logs mismatch after retry: have %d, want %d", len(fed), len(chain.headers))
+	}
+}
+
+func TestFirstBlockSince(t *testing.T) {
+	chain := new(testScanChain)
+	chain.extend(100, 0)
+
+	tests := []struct {
+		timestamp, number uint64
+	}{
+		{0, 0}, {10, 1}, {55, 6}, {990, 99}, {2000, 99},
+	}
+	for _, tt := range tests {
+		number, err := firstBlockSince(chain, tt.timestamp)
+		if err != nil {
+			t.Fatalf("failed to find block: %v", err)
+		}
+		if number != tt.number {
+			t.Errorf("block of %d mismatch: have %d, want %d", tt.timestamp, number, tt.number)
+		}
+	}
+}
+
+// Tests that a node upgraded with the root chain block of the last handled
+// event resumes after it, and a new node starts from the deployment block.
+func TestScanStart(t *testing.T) {
+	deployed := false
+	deployment := func() (uint64, error) {
+		deployed = true
+		return 7, nil
+	}
+
+	// upgraded node without checkpoint
+	store := new(testCheckpointStore)
+	start, err := scanStart(store, 42, deployment)
+	if err != nil {
+		t.Fatalf("failed to get scan start: %v", err)
+	}
+	if start != 43 || deployed {
+		t.Fatalf("upgrade start mismatch: have %d (deployment read: %v), want 43", start, deployed)
+	}
+	if next := newLogScanner(new(testScanChain), store, common.Address{}, start, nil).next; next != 43 {
+		t.Fatalf("scanner start mismatch: have %d, want 43", next)
+	}
+
+	// new node
+	if start, err = scanStart(store, 0, deployment); err != nil {
+		t.Fatalf("failed to get scan start: %v", err)
+	}
+	if start != 7 || !deployed {
+		t.Fatalf("new node start mismatch: have %d, want 7", start)
+	}
+
+	// checkpointed node resumes from the checkpoint
+	deployed = false
+	store.SetRootchainCheckpoint(100, common.Hash{1})
+	if start, err = scanStart(store, 42, deployment); err != nil {
+		t.Fatalf("failed to get scan start: %v", err)
+	}
+	if deployed {
+		t.Fatal("deployment block read with checkpoint")
+	}
+	if next := newLogScanner(new(testScanChain), store, common.Address{}, start, nil).next; next != 101 {
+		t.Fatalf("scanner start mismatch: have %d, want 101", next)
+	}
+}
+
+func TestEpochApplied(t *testing.T) {
+	tests := []struct {
+		fork, epoch int64
+		applied     bool
+	}{
+		{1, 4, true},  // previous epoch
+		{1, 5, true},  // current epoch
+		{1, 6, false}, // next epoch
+		{0, 9, true},  // previous fork
+		{2, 1, false}, // next fork
+	}
+	for i, tt := range tests {
+		if applied := epochApplied(big.NewInt(1), big.NewInt(5), big.NewInt(tt.fork), big.NewInt(tt.epoch)); applied != tt.applied {
+			t.Errorf("test %d: applied mismatch: have %v, want %v", i, applied, tt.applied)
+		}
+	}
+}
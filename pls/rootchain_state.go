@@ -1,9 +1,23 @@
 package pls
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"sync"
 	"time"
+
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/log"
+)
+
+const (
+	// number of root chain blocks to estimate the root chain block time
+	blockTimeSampleBlocks = 100
+
+	// root chain block time used if it cannot be estimated
+	defaultRootchainBlockTime = 15 * time.Second
 )
 
 type rootchainState struct {
@@ -18,11 +32,18 @@ type rootchainState struct {
 	costNRB        uint64
 	maxRequests    uint64
 	requestGas     uint64
+	prepareTimeout uint64 // in seconds
+	cpExit         uint64 // in seconds
 	lastEpoch      uint64
 	currentFork    uint64
 
 	lastUpdateTime time.Time
 
+	// root chain head and block time cached to estimate deadlines without
+	// root chain calls
+	head      *types.Header
+	blockTime time.Duration
+
 	lock sync.Mutex
 }
 
@@ -37,12 +58,85 @@ func newRootchainState(rcm *RootChainManager) *rootchainState {
 	rs.costNRB = rs.getCostNRB()
 	rs.maxRequests = rs.getMaxRequests()
 	rs.requestGas = rs.getRequestGas()
+	rs.prepareTimeout = rs.getPrepareTimeout()
+	rs.cpExit = rs.getCPExit()
 	rs.lastEpoch = rs.getLastEpoch()
 	rs.currentFork = rs.getCurrentFork()
 
 	return rs
 }
 
+// requestTxGasLimit returns the gas limit of the request transactions. Every
+// request, ether enters included, is applied with the REQUESTGAS budget, as
+// RequestBlockBodies builds them.
+func (rs *rootchainState) requestTxGasLimit() uint64 {
+	return plasma.RequestTxGasLimit(rs.requestGas)
+}
+
+// maxORBGas returns the gas required to apply a request block full of requests.
+func (rs *rootchainState) maxORBGas() uint64 {
+	return rs.maxRequests * rs.requestTxGasLimit()
+}
+
+// updateHead caches the root chain head and the block time estimated from the
+// recent blocks.
+func (rs *rootchainState) updateHead() error {
+	head, err := rs.rcm.backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	blockTime := defaultRootchainBlockTime
+	if n := head.Number.Uint64(); n > blockTimeSampleBlocks {
+		past, err := rs.rcm.backend.HeaderByNumber(context.Background(), new(big.Int).SetUint64(n-blockTimeSampleBlocks))
+		if err == nil && head.Time > past.Time {
+			blockTime = time.Duration(head.Time-past.Time) * time.Second / blockTimeSampleBlocks
+		}
+	}
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.head, rs.blockTime = head, blockTime
+	return nil
+}
+
+// trackHead updates the cached root chain head until quit is closed.
+func (rs *rootchainState) trackHead(quit chan struct{}) {
+	ticker := time.NewTicker(scanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := rs.updateHead(); err != nil {
+				log.Warn("Failed to update root chain head", "err", err)
+			}
+		case <-quit:
+			return
+		}
+	}
+}
+
+// deadlineAt returns the root chain block number expected to be mined at the
+// given time. The current block number is returned if the time has passed.
+// It is estimated from the cached root chain head without root chain calls.
+func (rs *rootchainState) deadlineAt(t time.Time) (uint64, error) {
+	rs.lock.Lock()
+	head, blockTime := rs.head, rs.blockTime
+	rs.lock.Unlock()
+
+	if head == nil {
+		return 0, errors.New("root chain head is unknown")
+	}
+
+	remaining := t.Sub(time.Unix(int64(head.Time), 0))
+	if remaining <= 0 || blockTime <= 0 {
+		return head.Number.Uint64(), nil
+	}
+	return head.Number.Uint64() + uint64(remaining/blockTime), nil
+}
+
 func (rs *rootchainState) getCostERU() uint64 {
 	r, _ := rs.rcm.rootchainContract.COSTERU(baseCallOpt)
 	return r.Uint64()
@@ -75,6 +169,14 @@ func (rs *rootchainState) getRequestGas() uint64 {
 	r, _ := rs.rcm.rootchainContract.REQUESTGAS(baseCallOpt)
 	return r.Uint64()
 }
+func (rs *rootchainState) getPrepareTimeout() uint64 {
+	r, _ := rs.rcm.rootchainContract.PREPARETIMEOUT(baseCallOpt)
+	return r.Uint64()
+}
+func (rs *rootchainState) getCPExit() uint64 {
+	r, _ := rs.rcm.rootchainContract.CPEXIT(baseCallOpt)
+	return r.Uint64()
+}
 func (rs *rootchainState) getLastEpoch() uint64 {
 	fork, _ := rs.rcm.rootchainContract.Forks(baseCallOpt, big.NewInt(int64(rs.currentFork)))
 	return fork.LastEpoch
@@ -0,0 +1,60 @@
+package pls
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+// Tests that deadlines are estimated from the cached head only.
+func TestDeadlineAt(t *testing.T) {
+	rs := new(rootchainState)
+	if _, err := rs.deadlineAt(time.Now()); err == nil {
+		t.Fatal("deadline estimated without root chain head")
+	}
+
+	rs.head = &types.Header{Number: big.NewInt(1000), Time: 10000}
+	rs.blockTime = 10 * time.Second
+
+	tests := []struct {
+		at       int64
+		deadline uint64
+	}{
+		{9000, 1000},  // passed
+		{10000, 1000}, // head
+		{10095, 1009},
+		{13600, 1360},
+	}
+	for _, tt := range tests {
+		deadline, err := rs.deadlineAt(time.Unix(tt.at, 0))
+		if err != nil {
+			t.Fatalf("failed to estimate deadline: %v", err)
+		}
+		if deadline != tt.deadline {
+			t.Errorf("deadline at %d mismatch: have %d, want %d", tt.at, deadline, tt.deadline)
+		}
+	}
+}
+
+// Tests that every request is given the REQUESTGAS budget, and the default gas
+// limit if REQUESTGAS is not set.
+func TestRequestTxGasLimit(t *testing.T) {
+	rs := &rootchainState{maxRequests: 10, requestGas: 50000}
+	if limit := rs.requestTxGasLimit(); limit != 50000 {
+		t.Errorf("request gas limit mismatch: have %d, want %d", limit, 50000)
+	}
+	if gas := rs.maxORBGas(); gas != 500000 {
+		t.Errorf("max ORB gas mismatch: have %d, want %d", gas, 500000)
+	}
+
+	rs.requestGas = 0
+	if limit := rs.requestTxGasLimit(); limit != params.RequestTxGasLimit {
+		t.Errorf("default request gas limit mismatch: have %d, want %d", limit, params.RequestTxGasLimit)
+	}
+	if gas := rs.maxORBGas(); gas != 10*params.RequestTxGasLimit {
+		t.Errorf("default max ORB gas mismatch: have %d, want %d", gas, 10*params.RequestTxGasLimit)
+	}
+}
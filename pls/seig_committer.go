@@ -0,0 +1,169 @@
+package pls
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi/bind"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/seigmanager"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/metrics"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+const seigCheckInterval = 30 * time.Second
+
+var (
+	seigLastCommitGauge  = metrics.NewRegisteredGauge("pls/seig/lastcommit", nil)
+	seigUncommittedGauge = metrics.NewRegisteredGaugeFloat64("pls/seig/uncommitted", nil)
+	seigCommittedCounter = metrics.NewRegisteredCounter("pls/seig/committed", nil) // in 1e-9 TON
+	seigCommitCounter    = metrics.NewRegisteredCounter("pls/seig/commits", nil)
+	seigForcedCounter    = metrics.NewRegisteredCounter("pls/seig/forced", nil)
+)
+
+// seigCommitter tracks seigniorage commits of the root chain. SeigManager
+// commits seigniorage only when RootChain submits an epoch or a block, so if
+// the gap from the last commit exceeds the commit interval, it completes the
+// current NRE with empty blocks to make the epoch submitted.
+type seigCommitter struct {
+	rcm *RootChainManager
+
+	seigManagerAddr common.Address
+	seigManager     *seigmanager.SeigManager
+
+	lastCommitBlock uint64
+	forcing         bool // whether the committer is filling the current epoch
+}
+
+func (rcm *RootChainManager) runSeigCommitter() {
+	if rcm.config.NodeMode != ModeOperator {
+		return
+	}
+
+	seigManagerAddr, err := rcm.rootchainContract.SeigManager(baseCallOpt)
+	if err != nil {
+		log.Error("Failed to read SeigManager address", "err", err)
+		return
+	}
+	if (seigManagerAddr == common.Address{}) {
+		log.Info("SeigManager is not registered to RootChain, seigniorage is not tracked")
+		return
+	}
+
+	seigManager, err := seigmanager.NewSeigManager(seigManagerAddr, rcm.backend)
+	if err != nil {
+		log.Error("Failed to load SeigManager contract", "err", err)
+		return
+	}
+
+	sc := &seigCommitter{
+		rcm:             rcm,
+		seigManagerAddr: seigManagerAddr,
+		seigManager:     seigManager,
+	}
+
+	ticker := time.NewTicker(seigCheckInterval)
+	defer ticker.Stop()
+
+	sc.check()
+
+	for {
+		select {
+		case <-ticker.C:
+			sc.check()
+
+		case <-rcm.quit:
+			return
+		}
+	}
+}
+
+// check updates seigniorage metrics and starts completing the current epoch if
+// seigniorage is not committed for the commit interval.
+func (sc *seigCommitter) check() {
+	rcm := sc.rcm
+
+	p, err := plasma.ProjectSeigniorage(rcm.backend, sc.seigManagerAddr, rcm.config.RootChainContract, nil, 0)
+	if err != nil {
+		log.Warn("Failed to project seigniorage", "err", err)
+		return
+	}
+
+	seigLastCommitGauge.Update(int64(p.LastCommitBlock))
+	seigUncommittedGauge.Update(params.ToRayFloat64(p.UncommittedSeig))
+
+	if p.LastCommitBlock > sc.lastCommitBlock {
+		if sc.lastCommitBlock != 0 {
+			sc.countCommitted(sc.lastCommitBlock+1, p.LastCommitBlock)
+		}
+		sc.lastCommitBlock = p.LastCommitBlock
+
+		if sc.forcing {
+			log.Info("Seigniorage is committed", "lastCommitBlock", p.LastCommitBlock)
+			sc.forcing = false
+		}
+	}
+
+	interval := rcm.config.SeigCommitInterval
+	if interval == 0 || p.Paused || p.BlockNumber < p.LastCommitBlock+interval {
+		return
+	}
+
+	if !sc.forcing {
+		seigForcedCounter.Inc(1)
+		log.Warn("Seigniorage is not committed for a while", "lastCommitBlock", p.LastCommitBlock, "blockNumber", p.BlockNumber,
+			"uncommitted", params.ToRayFloat64(p.UncommittedSeig))
+	}
+
+	sc.forcing = true
+	sc.complete()
+}
+
+// countCommitted adds seigniorage given to the root chain between the root
+// chain blocks to the metrics.
+func (sc *seigCommitter) countCommitted(start, end uint64) {
+	opts := &bind.FilterOpts{Start: start, End: &end, Context: context.Background()}
+
+	iter, err := sc.seigManager.FilterSeigGiven(opts, []common.Address{sc.rcm.config.RootChainContract})
+	if err != nil {
+		log.Warn("Failed to filter SeigGiven events", "err", err)
+		return
+	}
+	defer iter.Close()
+
+	for iter.Next() {
+		ev := iter.Event
+		seigCommittedCounter.Inc(new(big.Int).Div(ev.TotalSeig, big.NewInt(params.Ether)).Int64())
+		seigCommitCounter.Inc(1)
+
+		log.Info("Seigniorage committed", "totalSeig", params.ToRayFloat64(ev.TotalSeig), "stakedSeig", params.ToRayFloat64(ev.StakedSeig),
+			"powertonSeig", params.ToRayFloat64(ev.PowertonSeig), "blockNumber", ev.Raw.BlockNumber)
+	}
+}
+
+// complete makes the miner complete the current NRE with empty blocks if it is
+// still being mined. The completed epoch is submitted through the transaction
+// manager by addEpochSubmitTransaction, and the submission commits seigniorage.
+func (sc *seigCommitter) complete() {
+	rcm := sc.rcm
+
+	rcm.minerEnv.Lock()
+	isRequest, completed := rcm.minerEnv.IsRequest, rcm.minerEnv.Completed
+	rcm.minerEnv.Unlock()
+
+	// ORB epoch and completed NRE are submitted anyway, which commits seigniorage
+	if isRequest || completed || !rcm.miner.Mining() {
+		return
+	}
+
+	// a pending submission commits seigniorage when it is mined
+	if rcm.txManager.NumPending(rcm.config.Operator.Address) > 0 {
+		return
+	}
+
+	rcm.miner.CompleteEpoch()
+	log.Debug("Completing NRE with empty blocks to commit seigniorage", "epoch", rcm.minerEnv.EpochNumber)
+}
@@ -0,0 +1,510 @@
+package pls
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/metrics"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+	"github.com/prometheus/tsdb/fileutil"
+)
+
+const (
+	leaseLockRetries  = 20
+	leaseLockInterval = 50 * time.Millisecond
+)
+
+var (
+	errLeaseHeld         = errors.New("operator lease is held by another node")
+	errLeaseLost         = errors.New("operator lease is lost")
+	errSubmissionClaimed = errors.New("submission is claimed by a previous lease term")
+)
+
+var (
+	leaseTermGauge       = metrics.NewRegisteredGauge("pls/standby/term", nil)
+	standbyTakeoverMeter = metrics.NewRegisteredMeter("pls/standby/takeover", nil)
+	journalSeqGauge      = metrics.NewRegisteredGauge("pls/standby/journal", nil)
+)
+
+// leaseState is the content of the lease file shared by the primary and the
+// standby operator nodes. Every acquisition starts a new term, and the last
+// submission claimed is recorded with the term which claimed it, so that a
+// node whose term has ended can never submit again.
+type leaseState struct {
+	Holder string `json:"holder"`
+	Term   uint64 `json:"term"`
+	Expiry int64  `json:"expiry"` // unix time in nanoseconds
+
+	ClaimFork  uint64 `json:"claimFork"`
+	ClaimEpoch uint64 `json:"claimEpoch"`
+	ClaimBlock uint64 `json:"claimBlock"`
+	ClaimTerm  uint64 `json:"claimTerm"`
+}
+
+// lease is the operator lease stored in a file. The file is locked while it is
+// read and updated, so the lease file must be on a storage shared by the nodes
+// which supports file locks.
+//
+// The expiry in the lease file is written with the clock of the holder and
+// read with the clock of the other nodes, so the clocks of the nodes must be
+// synchronized, e.g. by NTP, well within the lease TTL. The holder itself stops
+// sending by its own monotonic clock as soon as the TTL passes since the last
+// acquisition or renewal, which is never later than the expiry it wrote.
+type lease struct {
+	path   string
+	holder string
+	ttl    time.Duration
+
+	term      uint64    // term of the lease held by this node, zero if not held
+	heldUntil time.Time // local time until which the lease of the term is held
+	lock      sync.Mutex
+}
+
+func newLease(path, holder string, ttl time.Duration) *lease {
+	return &lease{
+		path:   path,
+		holder: holder,
+		ttl:    ttl,
+	}
+}
+
+// update reads the lease state, applies fn and writes it back while the lease
+// file is locked.
+func (l *lease) update(fn func(s *leaseState) error) error {
+	var (
+		release fileutil.Releaser
+		err     error
+	)
+	for i := 0; i < leaseLockRetries; i++ {
+		if release, _, err = fileutil.Flock(l.path + ".lock"); err == nil {
+			break
+		}
+		time.Sleep(leaseLockInterval)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock lease file: %v", err)
+	}
+	defer release.Release()
+
+	s := new(leaseState)
+	data, err := ioutil.ReadFile(l.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, s); err != nil {
+			return fmt.Errorf("invalid lease file: %v", err)
+		}
+	}
+
+	if err := fn(s); err != nil {
+		return err
+	}
+
+	updated, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, updated) {
+		return nil
+	}
+	tmp := l.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, updated, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
+
+// acquire takes the lease with a new term if it is not held by another node.
+func (l *lease) acquire() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	start := time.Now()
+	return l.update(func(s *leaseState) error {
+		if s.Holder != l.holder && time.Now().UnixNano() < s.Expiry {
+			return errLeaseHeld
+		}
+		s.Holder = l.holder
+		s.Term++
+		s.Expiry = time.Now().Add(l.ttl).UnixNano()
+
+		l.term, l.heldUntil = s.Term, start.Add(l.ttl)
+		leaseTermGauge.Update(int64(s.Term))
+		return nil
+	})
+}
+
+// renew extends the lease of the current term.
+func (l *lease) renew() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	start := time.Now()
+	return l.update(func(s *leaseState) error {
+		if l.term == 0 || s.Term != l.term || s.Holder != l.holder {
+			l.term = 0
+			return errLeaseLost
+		}
+		s.Expiry = time.Now().Add(l.ttl).UnixNano()
+		l.heldUntil = start.Add(l.ttl)
+		return nil
+	})
+}
+
+// isHeld returns whether the lease of the current term is held, judged by the
+// local clock from the last acquisition or renewal.
+func (l *lease) isHeld() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.term != 0 && time.Now().Before(l.heldUntil)
+}
+
+// claim records the submission of the block for the epoch. It fails if the
+// lease is not held anymore, or if a previous term claimed the same or a later
+// submission of the fork.
+func (l *lease) claim(fork, epoch, block uint64) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.update(func(s *leaseState) error {
+		if l.term == 0 || s.Term != l.term || s.Holder != l.holder || time.Now().UnixNano() >= s.Expiry {
+			return errLeaseLost
+		}
+		if s.ClaimTerm != 0 && s.ClaimTerm != l.term && s.ClaimFork == fork && s.ClaimBlock >= block {
+			return errSubmissionClaimed
+		}
+		s.ClaimFork, s.ClaimEpoch, s.ClaimBlock, s.ClaimTerm = fork, epoch, block, l.term
+		return nil
+	})
+}
+
+// release expires the lease of the current term so that the standby takes
+// over without waiting for the lease to expire.
+func (l *lease) release() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.term == 0 {
+		return nil
+	}
+	return l.update(func(s *leaseState) error {
+		if s.Term == l.term && s.Holder == l.holder {
+			s.Expiry = 0
+		}
+		l.term = 0
+		return nil
+	})
+}
+
+// state reads the lease state.
+func (l *lease) state() (*leaseState, error) {
+	var state leaseState
+	err := l.update(func(s *leaseState) error {
+		state = *s
+		return nil
+	})
+	return &state, err
+}
+
+// standby runs the operator in a hot standby pair. The primary holds the lease
+// and serves the journal of its epoch environment and transaction manager. The
+// standby replicates the journal, and takes over sealing and submission once
+// the lease of the primary expires.
+type standby struct {
+	rcm     *RootChainManager
+	lease   *lease
+	journal *journalDB
+
+	primary      string // RPC endpoint of the primary, empty for the primary
+	primaryEpoch uint64 // journal epoch of the primary process replicated
+	primarySeq   uint64 // last journal sequence number replicated
+	primaryHead  uint64 // last block number of the primary
+
+	active bool
+	lock   sync.RWMutex
+}
+
+func newStandby(rcm *RootChainManager, journal *journalDB) *standby {
+	host, _ := os.Hostname()
+	holder := fmt.Sprintf("%s:%d", host, os.Getpid())
+
+	return &standby{
+		rcm:     rcm,
+		lease:   newLease(rcm.config.OperatorLeaseFile, holder, rcm.config.OperatorLeaseTTL),
+		journal: journal,
+		primary: rcm.config.StandbyPrimary,
+	}
+}
+
+// start takes the lease and activates the operator if the node is the
+// primary, or starts following the primary.
+func (sb *standby) start() error {
+	if sb.primary != "" {
+		log.Info("Operator is in standby", "primary", sb.primary, "lease", sb.lease.path)
+		go sb.follow()
+		return nil
+	}
+
+	if err := sb.lease.acquire(); err != nil {
+		return err
+	}
+	sb.activate()
+	return nil
+}
+
+func (sb *standby) stop() {
+	if err := sb.lease.release(); err != nil {
+		log.Warn("Failed to release operator lease", "err", err)
+	}
+}
+
+func (sb *standby) isActive() bool {
+	sb.lock.RLock()
+	defer sb.lock.RUnlock()
+
+	return sb.active
+}
+
+// activate starts sealing and submission, and keeps renewing the lease. The
+// node is stopped as soon as the lease cannot be renewed.
+func (sb *standby) activate() {
+	sb.lock.Lock()
+	sb.active = true
+	sb.lock.Unlock()
+
+	log.Info("Operator lease acquired", "holder", sb.lease.holder, "term", sb.lease.term)
+	sb.rcm.activate()
+
+	go func() {
+		ticker := time.NewTicker(sb.lease.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := sb.lease.renew(); err != nil {
+					log.Error("Failed to renew operator lease, stopping operator", "err", err)
+					sb.rcm.stopFn()
+					return
+				}
+			case <-sb.rcm.quit:
+				return
+			}
+		}
+	}()
+}
+
+// follow replicates the journal of the primary, and takes over once the lease
+// of the primary expires and the local chain caught up with the primary.
+func (sb *standby) follow() {
+	ticker := time.NewTicker(sb.lease.ttl / 3)
+	defer ticker.Stop()
+
+	var client *rpc.Client
+	defer func() {
+		if client != nil {
+			client.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-ticker.C:
+			if client == nil {
+				var err error
+				if client, err = rpc.Dial(sb.primary); err != nil {
+					log.Warn("Failed to connect primary operator", "err", err)
+					client = nil
+				}
+			}
+			if client != nil {
+				if err := sb.replicate(client); err != nil {
+					log.Warn("Failed to replicate primary operator journal", "err", err)
+				}
+			}
+
+			if head := sb.rcm.blockchain.CurrentBlock().NumberU64(); head < sb.primaryHead {
+				log.Debug("Standby chain is behind the primary", "head", head, "primary", sb.primaryHead)
+				continue
+			}
+			if err := sb.lease.acquire(); err == errLeaseHeld {
+				continue
+			} else if err != nil {
+				log.Error("Failed to acquire operator lease", "err", err)
+				continue
+			}
+
+			if err := sb.takeover(); err != nil {
+				log.Error("Failed to take over operator", "err", err)
+				sb.rcm.stopFn()
+			}
+			return
+
+		case <-sb.rcm.quit:
+			return
+		}
+	}
+}
+
+// replicate applies the journal entries written by the primary since the last
+// replication.
+func (sb *standby) replicate(client *rpc.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sb.lease.ttl/3)
+	defer cancel()
+
+	return sb.sync(func(from uint64) (*JournalDump, error) {
+		var dump JournalDump
+		err := client.CallContext(ctx, &dump, "standby_journal", hexutil.Uint64(from))
+		return &dump, err
+	})
+}
+
+// sync fetches the journal entries of the primary since the last replication
+// and applies them. The journal sequence restarts with the primary process, so
+// the whole journal is fetched again once the primary is restarted.
+func (sb *standby) sync(fetch func(from uint64) (*JournalDump, error)) error {
+	sb.lock.RLock()
+	epoch, seq := sb.primaryEpoch, sb.primarySeq
+	sb.lock.RUnlock()
+
+	dump, err := fetch(seq)
+	if err != nil {
+		return err
+	}
+
+	resync := uint64(dump.Epoch) != epoch || uint64(dump.Seq) < seq
+	if resync && seq != 0 {
+		log.Warn("Primary operator journal restarted, replicating it again", "epoch", dump.Epoch, "seq", dump.Seq, "replicated", seq)
+		if dump, err = fetch(0); err != nil {
+			return err
+		}
+	}
+	if resync {
+		err = sb.journal.reset(dump.Entries)
+	} else {
+		err = sb.journal.apply(dump.Entries)
+	}
+	if err != nil {
+		return err
+	}
+
+	sb.lock.Lock()
+	sb.primaryEpoch, sb.primarySeq, sb.primaryHead = uint64(dump.Epoch), uint64(dump.Seq), uint64(dump.Head)
+	sb.lock.Unlock()
+
+	journalSeqGauge.Update(int64(dump.Seq))
+	if len(dump.Entries) > 0 {
+		log.Debug("Primary operator journal replicated", "entries", len(dump.Entries), "epoch", dump.Epoch, "seq", dump.Seq)
+	}
+	return nil
+}
+
+// takeover loads the replicated state and activates the operator.
+func (sb *standby) takeover() error {
+	log.Warn("Primary operator lease expired, taking over", "term", sb.lease.term, "seq", sb.primarySeq)
+	standbyTakeoverMeter.Mark(1)
+
+	if err := sb.rcm.txManager.Reload(); err != nil {
+		return err
+	}
+
+	// queue the request blocks which the primary did not mine
+	env := rawdb.ReadEpochEnv(sb.journal)
+	if env != nil && env.IsRequest && !env.Completed && env.EpochLength.Sign() > 0 {
+		bodies, err := plasma.RequestBlockBodies(sb.rcm.rootchainContract, baseCallOpt, env.CurrentFork, env.EpochNumber, sb.rcm.state.requestGas)
+		if err != nil {
+			return err
+		}
+		if mined := env.NumBlockMined.Uint64(); mined < uint64(len(bodies)) {
+			start := new(big.Int).Add(env.StartBlockNumber, env.NumBlockMined)
+			if err := sb.rcm.miner.EnqueueORBs(env.CurrentFork, env.EpochNumber, start, bodies[mined:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	sb.activate()
+	return nil
+}
+
+// checkLease fences the transactions sent to the root chain by the lease.
+func (sb *standby) checkLease() error {
+	if !sb.lease.isHeld() {
+		return errLeaseLost
+	}
+	return nil
+}
+
+// claim fences the submission of the block for the epoch by the lease.
+func (sb *standby) claim(fork, epoch, block *big.Int) error {
+	return sb.lease.claim(fork.Uint64(), epoch.Uint64(), block.Uint64())
+}
+
+// PrivateStandbyAPI provides an API to replicate the operator between the
+// primary and the standby.
+type PrivateStandbyAPI struct {
+	p *Plasma
+}
+
+// NewPrivateStandbyAPI creates a new standby API.
+func NewPrivateStandbyAPI(p *Plasma) *PrivateStandbyAPI {
+	return &PrivateStandbyAPI{p}
+}
+
+// Journal returns the journal entries written after the sequence number.
+func (api *PrivateStandbyAPI) Journal(from hexutil.Uint64) (*JournalDump, error) {
+	sb := api.p.rootchainManager.standby
+	if sb == nil {
+		return nil, errors.New("operator lease is not configured")
+	}
+	if !sb.isActive() {
+		return nil, errors.New("operator is not active")
+	}
+
+	return sb.journal.dump(uint64(from), api.p.blockchain.CurrentBlock().NumberU64()), nil
+}
+
+// Status returns the lease and the replication status of the operator.
+func (api *PrivateStandbyAPI) Status() (map[string]interface{}, error) {
+	sb := api.p.rootchainManager.standby
+	if sb == nil {
+		return nil, errors.New("operator lease is not configured")
+	}
+
+	s, err := sb.lease.state()
+	if err != nil {
+		return nil, err
+	}
+
+	sb.lock.RLock()
+	defer sb.lock.RUnlock()
+
+	return map[string]interface{}{
+		"active":       sb.active,
+		"holder":       s.Holder,
+		"term":         hexutil.Uint64(s.Term),
+		"expiry":       time.Unix(0, s.Expiry),
+		"claimFork":    hexutil.Uint64(s.ClaimFork),
+		"claimEpoch":   hexutil.Uint64(s.ClaimEpoch),
+		"claimBlock":   hexutil.Uint64(s.ClaimBlock),
+		"claimTerm":    hexutil.Uint64(s.ClaimTerm),
+		"primary":      sb.primary,
+		"primaryEpoch": hexutil.Uint64(sb.primaryEpoch),
+		"primarySeq":   hexutil.Uint64(sb.primarySeq),
+		"primaryHead":  hexutil.Uint64(sb.primaryHead),
+	}, nil
+}
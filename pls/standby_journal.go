@@ -0,0 +1,231 @@
+package pls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/ethdb"
+	"github.com/Onther-Tech/plasma-evm/tx"
+)
+
+// JournalEntry is the latest write of a database key made by the miner or the
+// transaction manager.
+type JournalEntry struct {
+	Seq     hexutil.Uint64 `json:"seq"`
+	Key     hexutil.Bytes  `json:"key"`
+	Value   hexutil.Bytes  `json:"value"`
+	Deleted bool           `json:"deleted"`
+}
+
+// JournalDump is a part of the journal sent from the primary to the standby.
+type JournalDump struct {
+	Epoch   hexutil.Uint64  `json:"epoch"` // random identifier of the primary process
+	Seq     hexutil.Uint64  `json:"seq"`   // last sequence number of the journal
+	Head    hexutil.Uint64  `json:"head"`  // current block number of the primary
+	Entries []*JournalEntry `json:"entries"`
+}
+
+// journalDB wraps the database of the epoch environment and the transaction
+// manager, and records every write so that a standby operator can replicate
+// them. Only the latest write of each key is kept, so the journal is as large
+// as the replicated data. The sequence numbers start over in each process, so
+// the journal is identified by a random epoch.
+type journalDB struct {
+	ethdb.Database
+
+	epoch   uint64
+	seq     uint64
+	entries map[string]*JournalEntry
+
+	lock sync.RWMutex
+}
+
+func newJournalDB(db ethdb.Database) *journalDB {
+	j := &journalDB{
+		Database: db,
+		epoch:    newJournalEpoch(),
+		entries:  make(map[string]*JournalEntry),
+	}
+
+	// record the existing data so that a new standby receives everything
+	for _, prefix := range tx.KeyPrefixes() {
+		it := db.NewIteratorWithPrefix(prefix)
+		for it.Next() {
+			j.record(common.CopyBytes(it.Key()), common.CopyBytes(it.Value()), false)
+		}
+		it.Release()
+	}
+	if env := rawdb.ReadEpochEnv(db); env != nil && env.EpochLength.Sign() > 0 {
+		rawdb.WriteEpochEnv(j, env)
+	}
+	return j
+}
+
+// newJournalEpoch returns a random non-zero journal epoch.
+func newJournalEpoch() uint64 {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			panic("failed to read random journal epoch: " + err.Error())
+		}
+		if epoch := binary.BigEndian.Uint64(b[:]); epoch != 0 {
+			return epoch
+		}
+	}
+}
+
+func (j *journalDB) record(key, value []byte, deleted bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.seq++
+	j.entries[string(key)] = &JournalEntry{
+		Seq:     hexutil.Uint64(j.seq),
+		Key:     key,
+		Value:   value,
+		Deleted: deleted,
+	}
+}
+
+// Put inserts the given value into the database and records it.
+func (j *journalDB) Put(key []byte, value []byte) error {
+	if err := j.Database.Put(key, value); err != nil {
+		return err
+	}
+	j.record(common.CopyBytes(key), common.CopyBytes(value), false)
+	return nil
+}
+
+// Delete removes the key from the database and records it.
+func (j *journalDB) Delete(key []byte) error {
+	if err := j.Database.Delete(key); err != nil {
+		return err
+	}
+	j.record(common.CopyBytes(key), nil, true)
+	return nil
+}
+
+// NewBatch creates a batch whose writes are recorded once it is written.
+func (j *journalDB) NewBatch() ethdb.Batch {
+	return &journalBatch{Batch: j.Database.NewBatch(), journal: j}
+}
+
+// since returns the entries written after the given sequence number in order.
+func (j *journalDB) since(seq uint64) ([]*JournalEntry, uint64) {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
+	var entries []*JournalEntry
+	for _, e := range j.entries {
+		if uint64(e.Seq) > seq {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Seq < entries[b].Seq })
+	return entries, j.seq
+}
+
+// dump returns the part of the journal written after the sequence number.
+func (j *journalDB) dump(from, head uint64) *JournalDump {
+	entries, seq := j.since(from)
+	return &JournalDump{
+		Epoch:   hexutil.Uint64(j.epoch),
+		Seq:     hexutil.Uint64(seq),
+		Head:    hexutil.Uint64(head),
+		Entries: entries,
+	}
+}
+
+// reset replaces the replicated data with the whole journal of the primary.
+// The keys not in the journal are deleted, since the primary may have deleted
+// them while the journal was not replicated.
+func (j *journalDB) reset(entries []*JournalEntry) error {
+	keep := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		keep[string(e.Key)] = true
+	}
+
+	var stale [][]byte
+	for _, prefix := range tx.KeyPrefixes() {
+		it := j.Database.NewIteratorWithPrefix(prefix)
+		for it.Next() {
+			if !keep[string(it.Key())] {
+				stale = append(stale, common.CopyBytes(it.Key()))
+			}
+		}
+		it.Release()
+	}
+	for _, key := range stale {
+		if err := j.Delete(key); err != nil {
+			return err
+		}
+	}
+	return j.apply(entries)
+}
+
+// apply writes the entries replicated from the primary.
+func (j *journalDB) apply(entries []*JournalEntry) error {
+	for _, e := range entries {
+		var err error
+		if e.Deleted {
+			err = j.Delete(e.Key)
+		} else {
+			err = j.Put(e.Key, e.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type journalOp struct {
+	key, value []byte
+	deleted    bool
+}
+
+// journalBatch records the writes of a batch to the journal after it is
+// written to the database.
+type journalBatch struct {
+	ethdb.Batch
+
+	journal *journalDB
+	ops     []journalOp
+}
+
+func (b *journalBatch) Put(key []byte, value []byte) error {
+	if err := b.Batch.Put(key, value); err != nil {
+		return err
+	}
+	b.ops = append(b.ops, journalOp{key: common.CopyBytes(key), value: common.CopyBytes(value)})
+	return nil
+}
+
+func (b *journalBatch) Delete(key []byte) error {
+	if err := b.Batch.Delete(key); err != nil {
+		return err
+	}
+	b.ops = append(b.ops, journalOp{key: common.CopyBytes(key), deleted: true})
+	return nil
+}
+
+func (b *journalBatch) Write() error {
+	if err := b.Batch.Write(); err != nil {
+		return err
+	}
+	for _, op := range b.ops {
+		b.journal.record(op.key, op.value, op.deleted)
+	}
+	b.ops = nil
+	return nil
+}
+
+func (b *journalBatch) Reset() {
+	b.Batch.Reset()
+	b.ops = nil
+}
@@ -0,0 +1,149 @@
+package pls
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+)
+
+// Tests that a node whose lease term has ended can neither renew the lease
+// nor claim a submission, and that the next term cannot claim a submission
+// which the previous term already claimed.
+func TestLeaseFencing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plasma-lease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lease")
+	primary := newLease(path, "primary", 50*time.Millisecond)
+	standby := newLease(path, "standby", 50*time.Millisecond)
+
+	if err := primary.acquire(); err != nil {
+		t.Fatalf("failed to acquire lease: %v", err)
+	}
+	if err := standby.acquire(); err != errLeaseHeld {
+		t.Fatalf("acquire error mismatch: have %v, want %v", err, errLeaseHeld)
+	}
+	if err := primary.claim(0, 3, 10); err != nil {
+		t.Fatalf("failed to claim submission: %v", err)
+	}
+
+	if !primary.isHeld() || standby.isHeld() {
+		t.Fatalf("lease holder mismatch: primary %v, standby %v", primary.isHeld(), standby.isHeld())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if primary.isHeld() {
+		t.Fatalf("expired lease is held")
+	}
+	if err := standby.acquire(); err != nil {
+		t.Fatalf("failed to acquire expired lease: %v", err)
+	}
+	if err := primary.claim(0, 4, 11); err != errLeaseLost {
+		t.Fatalf("claim error mismatch: have %v, want %v", err, errLeaseLost)
+	}
+	if err := primary.renew(); err != errLeaseLost {
+		t.Fatalf("renew error mismatch: have %v, want %v", err, errLeaseLost)
+	}
+
+	if err := standby.claim(0, 3, 10); err != errSubmissionClaimed {
+		t.Fatalf("claim error mismatch: have %v, want %v", err, errSubmissionClaimed)
+	}
+	if err := standby.claim(0, 4, 11); err != nil {
+		t.Fatalf("failed to claim next submission: %v", err)
+	}
+	if err := standby.claim(0, 4, 11); err != nil {
+		t.Fatalf("failed to claim submission again in the same term: %v", err)
+	}
+
+	s, err := standby.state()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Holder != "standby" || s.Term != 2 || s.ClaimBlock != 11 || s.ClaimTerm != 2 {
+		t.Fatalf("lease state mismatch: %+v", s)
+	}
+}
+
+// Tests that the journal replicates the writes made through it, including the
+// data written before it was opened.
+func TestJournalReplication(t *testing.T) {
+	primaryDb := rawdb.NewMemoryDatabase()
+	primaryDb.Put([]byte("gas-price"), []byte{1})
+
+	primary := newJournalDB(primaryDb)
+	primary.Put([]byte("pending-raw-txs"), []byte{2})
+	primary.Delete([]byte("gas-price"))
+
+	standby := newJournalDB(rawdb.NewMemoryDatabase())
+	standby.Put([]byte("gas-price"), []byte{3})
+
+	entries, seq := primary.since(0)
+	if len(entries) != 2 || seq != 3 {
+		t.Fatalf("journal mismatch: have %d entries at %d, want %d at %d", len(entries), seq, 2, 3)
+	}
+	if err := standby.apply(entries); err != nil {
+		t.Fatalf("failed to apply journal: %v", err)
+	}
+	if ok, _ := standby.Has([]byte("gas-price")); ok {
+		t.Fatalf("deleted key is replicated")
+	}
+	if v, _ := standby.Get([]byte("pending-raw-txs")); len(v) != 1 || v[0] != 2 {
+		t.Fatalf("value mismatch: have %x, want %x", v, []byte{2})
+	}
+
+	batch := primary.NewBatch()
+	batch.Put([]byte("address-nonce"), []byte{4})
+	if entries, _ := primary.since(seq); len(entries) != 0 {
+		t.Fatalf("unwritten batch is journaled")
+	}
+	batch.Write()
+	if entries, _ := primary.since(seq); len(entries) != 1 {
+		t.Fatalf("journal mismatch: have %d entries, want %d", len(entries), 1)
+	}
+}
+
+// Tests that the standby replicates the whole journal again once the primary
+// is restarted and its journal sequence starts over.
+func TestJournalPrimaryRestart(t *testing.T) {
+	primaryDb := rawdb.NewMemoryDatabase()
+	primary := newJournalDB(primaryDb)
+	for i := byte(0); i < 5; i++ {
+		primary.Put(append([]byte("address"), i), []byte{i})
+	}
+
+	sb := &standby{journal: newJournalDB(rawdb.NewMemoryDatabase())}
+	fetch := func(from uint64) (*JournalDump, error) {
+		return primary.dump(from, 0), nil
+	}
+	if err := sb.sync(fetch); err != nil {
+		t.Fatalf("failed to replicate journal: %v", err)
+	}
+	if sb.primarySeq != 5 {
+		t.Fatalf("replicated sequence mismatch: have %d, want %d", sb.primarySeq, 5)
+	}
+
+	// the restarted primary starts the journal over with the data in the database
+	primaryDb.Delete(append([]byte("address"), 0))
+	primary = newJournalDB(primaryDb)
+	primary.Put([]byte("gas-price"), []byte{9})
+
+	if err := sb.sync(fetch); err != nil {
+		t.Fatalf("failed to replicate restarted journal: %v", err)
+	}
+	if sb.primaryEpoch != primary.epoch || sb.primarySeq != 5 {
+		t.Fatalf("replicated journal mismatch: have epoch %d seq %d, want %d %d", sb.primaryEpoch, sb.primarySeq, primary.epoch, 5)
+	}
+	if v, _ := sb.journal.Get([]byte("gas-price")); len(v) != 1 || v[0] != 9 {
+		t.Fatalf("value mismatch: have %x, want %x", v, []byte{9})
+	}
+	if ok, _ := sb.journal.Has(append([]byte("address"), 0)); ok {
+		t.Fatalf("key deleted while the primary restarted is replicated")
+	}
+}
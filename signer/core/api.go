@@ -43,7 +43,7 @@ const (
 	// ExternalAPIVersion -- see extapi_changelog.md
 	ExternalAPIVersion = "6.0.0"
 	// InternalAPIVersion -- see intapi_changelog.md
-	InternalAPIVersion = "7.0.0"
+	InternalAPIVersion = "7.1.0"
 )
 
 // ExternalAPI defines the external API through which signing requests are made.
@@ -106,12 +106,23 @@ type Validator interface {
 	ValidateTransaction(selector *string, tx *SendTxArgs) (*ValidationMessages, error)
 }
 
+// CallDecoder decodes the call data of a transaction against the ABIs of known
+// contracts, so that the UI and rulesets can inspect the method and arguments.
+//
+// Use plasma.Decoder as an implementation for the plasma contracts.
+type CallDecoder interface {
+	// DecodeCall returns the decoded call, or nil if the recipient is not a
+	// known contract.
+	DecodeCall(to common.Address, data []byte) (*DecodedCall, error)
+}
+
 // SignerAPI defines the actual implementation of ExternalAPI
 type SignerAPI struct {
 	chainID     *big.Int
 	am          *accounts.Manager
 	UI          UIClientAPI
 	validator   Validator
+	decoder     CallDecoder
 	rejectMode  bool
 	credentials storage.Storage
 }
@@ -221,8 +232,17 @@ type (
 	SignTxRequest struct {
 		Transaction SendTxArgs       `json:"transaction"`
 		Callinfo    []ValidationInfo `json:"call_info"`
+		Decoded     *DecodedCall     `json:"decoded,omitempty"`
 		Meta        Metadata         `json:"meta"`
 	}
+	// DecodedCall is the call data of a transaction decoded against the ABI of
+	// a known contract
+	DecodedCall struct {
+		Contract  string                 `json:"contract"`
+		Method    string                 `json:"method"`
+		Signature string                 `json:"signature"`
+		Args      map[string]interface{} `json:"args"`
+	}
 	// SignTxResponse result from SignTxRequest
 	SignTxResponse struct {
 		//The UI may make changes to the TX
@@ -280,12 +300,17 @@ func NewSignerAPI(am *accounts.Manager, chainID int64, noUSB bool, ui UIClientAP
 	if advancedMode {
 		log.Info("Clef is in advanced mode: will warn instead of reject")
 	}
-	signer := &SignerAPI{big.NewInt(chainID), am, ui, validator, !advancedMode, credentials}
+	signer := &SignerAPI{big.NewInt(chainID), am, ui, validator, nil, !advancedMode, credentials}
 	if !noUSB {
 		signer.startUSBListener()
 	}
 	return signer
 }
+
+// SetCallDecoder sets the decoder of the transaction call data.
+func (api *SignerAPI) SetCallDecoder(decoder CallDecoder) {
+	api.decoder = decoder
+}
 func (api *SignerAPI) openTrezor(url accounts.URL) {
 	resp, err := api.UI.OnInputRequired(UserInputRequest{
 		Prompt: "Pin required to open Trezor wallet\n" +
@@ -517,6 +542,18 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args SendTxArgs, meth
 		Meta:        MetadataFromContext(ctx),
 		Callinfo:    msgs.Messages,
 	}
+	if api.decoder != nil && args.To != nil && args.Data != nil {
+		call, err := api.decoder.DecodeCall(args.To.Address(), *args.Data)
+		if err != nil {
+			// the request to a known contract must match its ABI
+			if api.rejectMode {
+				return nil, err
+			}
+			msgs.Warn(fmt.Sprintf("Transaction data could not be decoded: %v", err))
+			req.Callinfo = msgs.Messages
+		}
+		req.Decoded = call
+	}
 	// Process approval
 	result, err = api.UI.ApproveTx(&req)
 	if err != nil {
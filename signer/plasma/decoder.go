@@ -0,0 +1,146 @@
+// Package plasma decodes the call data of transactions to the plasma contracts
+// for the signer.
+package plasma
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/depositmanager"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+	stamina "github.com/Onther-Tech/plasma-evm/contracts/stamina/contract"
+	"github.com/Onther-Tech/plasma-evm/params"
+	"github.com/Onther-Tech/plasma-evm/signer/core"
+)
+
+var errShortCallData = errors.New("call data is shorter than method id")
+
+// Config is the addresses of the plasma contracts. The stamina contract is
+// always decoded at its predeployed address.
+type Config struct {
+	RootChain      common.Address
+	DepositManager common.Address
+}
+
+type contract struct {
+	name string
+	abi  abi.ABI
+}
+
+// Decoder decodes the call data of transactions to the plasma contracts.
+type Decoder struct {
+	contracts map[common.Address]*contract
+}
+
+// NewDecoder creates a decoder of the configured plasma contracts.
+func NewDecoder(config Config) (*Decoder, error) {
+	d := &Decoder{contracts: make(map[common.Address]*contract)}
+
+	if err := d.add("Stamina", params.StaminaAddress, stamina.StaminaABI); err != nil {
+		return nil, err
+	}
+	if (config.RootChain != common.Address{}) {
+		if err := d.add("RootChain", config.RootChain, rootchain.RootChainABI); err != nil {
+			return nil, err
+		}
+	}
+	if (config.DepositManager != common.Address{}) {
+		if err := d.add("DepositManager", config.DepositManager, depositmanager.DepositManagerABI); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *Decoder) add(name string, addr common.Address, abiJSON string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s ABI: %v", name, err)
+	}
+	d.contracts[addr] = &contract{name: name, abi: parsed}
+	return nil
+}
+
+// DecodeCall implements core.CallDecoder. Arguments are keyed by their names
+// without the leading underscore, integers are given as decimal strings and
+// bytes as hex strings, so that rulesets can compare them without losing
+// precision.
+func (d *Decoder) DecodeCall(to common.Address, data []byte) (*core.DecodedCall, error) {
+	c, ok := d.contracts[to]
+	if !ok {
+		return nil, nil
+	}
+	if len(data) < 4 {
+		return nil, errShortCallData
+	}
+
+	method, err := c.abi.MethodById(data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("unknown %s method %x", c.name, data[:4])
+	}
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s.%s: %v", c.name, method.Name, err)
+	}
+
+	args := make(map[string]interface{})
+	for i, input := range method.Inputs {
+		name := strings.TrimPrefix(input.Name, "_")
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		args[name] = normalize(reflect.ValueOf(values[i]))
+	}
+
+	return &core.DecodedCall{
+		Contract:  c.name,
+		Method:    method.Name,
+		Signature: method.Sig(),
+		Args:      args,
+	}, nil
+}
+
+// normalize converts the decoded value into a JSON value which JavaScript can
+// represent exactly.
+func normalize(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	switch x := v.Interface().(type) {
+	case *big.Int:
+		return x.String()
+	case common.Address:
+		return strings.ToLower(x.Hex())
+	case []byte:
+		return hexutil.Encode(x)
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(v.Interface())
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = normalize(v.Index(i))
+		}
+		return list
+	}
+	return v.Interface()
+}
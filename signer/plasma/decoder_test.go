@@ -0,0 +1,48 @@
+package plasma
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/accounts/abi"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/contracts/plasma/rootchain"
+)
+
+func TestDecodeCall(t *testing.T) {
+	rootchainAddr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	decoder, err := NewDecoder(Config{RootChain: rootchainAddr})
+	if err != nil {
+		t.Fatalf("failed to create decoder: %v", err)
+	}
+
+	rootchainABI, _ := abi.JSON(strings.NewReader(rootchain.RootChainABI))
+	pos := new(big.Int).Lsh(big.NewInt(1), 128)
+	root := common.HexToHash("0x01")
+	data, err := rootchainABI.Pack("submitORB", pos, root, root, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call, err := decoder.DecodeCall(rootchainAddr, data)
+	if err != nil {
+		t.Fatalf("failed to decode call: %v", err)
+	}
+	if call.Contract != "RootChain" || call.Method != "submitORB" {
+		t.Fatalf("decoded method mismatch: have %s.%s, want RootChain.submitORB", call.Contract, call.Method)
+	}
+	if have, want := call.Args["pos"], pos.String(); have != want {
+		t.Errorf("pos mismatch: have %v, want %v", have, want)
+	}
+	if have, want := call.Args["statesRoot"], root.Hex(); have != want {
+		t.Errorf("statesRoot mismatch: have %v, want %v", have, want)
+	}
+
+	if _, err := decoder.DecodeCall(rootchainAddr, data[:len(data)-1]); err == nil {
+		t.Errorf("malformed call data is decoded")
+	}
+	if call, err := decoder.DecodeCall(common.HexToAddress("0x01"), data); call != nil || err != nil {
+		t.Errorf("call to unknown contract is decoded: %v, %v", call, err)
+	}
+}
@@ -18,7 +18,9 @@ package rules
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math/big"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -194,6 +196,60 @@ func TestSignTxRequest(t *testing.T) {
 	}
 }
 
+// Tests that the shipped plasma operator ruleset approves only the decoded
+// block submissions of the operator within the gas caps.
+func TestPlasmaOperatorRules(t *testing.T) {
+	js, err := ioutil.ReadFile("../../cmd/clef/plasma-operator.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs := map[string]string{
+		"rootchain":  "0x00000000000000000000000000000000000000aa",
+		"operator":   "0x00000000000000000000000000000000000000bb",
+		"challenger": "0x00000000000000000000000000000000000000cc",
+	}
+	for name, addr := range addrs {
+		js = regexp.MustCompile(`(var `+name+` += )"0x0+"`).ReplaceAll(js, []byte(`${1}"`+addr+`"`))
+	}
+	r, err := initRuleEngine(string(js))
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+
+	submitORB := &core.DecodedCall{Contract: "RootChain", Method: "submitORB"}
+	tests := []struct {
+		from     string
+		gas      uint64
+		decoded  *core.DecodedCall
+		approved bool
+	}{
+		{addrs["operator"], 1000000, submitORB, true},
+		{addrs["challenger"], 1000000, submitORB, false},
+		{addrs["operator"], 8000000, submitORB, false},
+		{addrs["operator"], 1000000, nil, false},
+	}
+	for i, tt := range tests {
+		from, _ := mixAddr(tt.from)
+		to, _ := mixAddr(addrs["rootchain"])
+		resp, err := r.ApproveTx(&core.SignTxRequest{
+			Transaction: core.SendTxArgs{
+				From:     *from,
+				To:       to,
+				Gas:      hexutil.Uint64(tt.gas),
+				GasPrice: hexutil.Big(*big.NewInt(1e9)),
+			},
+			Decoded: tt.decoded,
+			Meta:    core.Metadata{Remote: "remoteip", Local: "localip", Scheme: "inproc"},
+		})
+		if err != nil {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+		if resp.Approved != tt.approved {
+			t.Errorf("test %d: approval mismatch: have %v, want %v", i, resp.Approved, tt.approved)
+		}
+	}
+}
+
 type dummyUI struct {
 	calls []string
 }
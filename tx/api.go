@@ -0,0 +1,54 @@
+package tx
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+)
+
+// PrivateTxManagerAPI provides an API to cancel or replace the pending root
+// chain transactions of the node.
+type PrivateTxManagerAPI struct {
+	tm *TransactionManager
+}
+
+// NewPrivateTxManagerAPI creates a new transaction manager API.
+func NewPrivateTxManagerAPI(tm *TransactionManager) *PrivateTxManagerAPI {
+	return &PrivateTxManagerAPI{tm}
+}
+
+// ReplaceArgs is the payload replacing the one of a pending raw transaction.
+type ReplaceArgs struct {
+	To          common.Address `json:"to"`
+	Gas         hexutil.Uint64 `json:"gas"`
+	Value       *hexutil.Big   `json:"value"`
+	Data        hexutil.Bytes  `json:"data"`
+	AllowRevert bool           `json:"allowRevert"`
+	Caption     string         `json:"caption"`
+}
+
+// Cancel cancels the pending raw transaction of the index sent from the account.
+func (api *PrivateTxManagerAPI) Cancel(from common.Address, index hexutil.Uint64) error {
+	return api.tm.Cancel(&RawTransaction{From: from, Index: uint64(index)})
+}
+
+// Replace replaces the payload of the pending raw transaction of the index
+// sent from the account.
+func (api *PrivateTxManagerAPI) Replace(from common.Address, index hexutil.Uint64, args ReplaceArgs) error {
+	if args.Gas == 0 {
+		return errors.New("missing gas limit")
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	caption := args.Caption
+	if caption == "" {
+		caption = "replace"
+	}
+
+	replacement := NewRawTransaction(from, uint64(args.Gas), &args.To, value, args.Data, args.AllowRevert, caption)
+	return api.tm.Replace(&RawTransaction{From: from, Index: uint64(index)}, replacement)
+}
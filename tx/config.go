@@ -13,6 +13,8 @@ var DefaultConfig = &Config{
 	MaxGasPrice: new(big.Int).SetInt64(100 * params.GWei),
 	Interval:    10 * time.Second,
 	ChainId:     new(big.Int).SetInt64(1),
+	Pipeline:    16,
+	GasPricer:   DefaultGasPricerConfig,
 }
 
 type Config struct {
@@ -21,4 +23,14 @@ type Config struct {
 	MaxGasPrice *big.Int
 	ChainId     *big.Int
 	Interval    time.Duration
+
+	// Maximum number of ORB submissions of an account sent ahead of the first
+	// unmined raw transaction at the sequential nonces, zero to send them one
+	// by one. Other raw transactions are always sent one by one.
+	Pipeline int
+
+	// Gas pricing strategy, and the strategies of caption kinds (e.g.
+	// "submitORB") overriding it. Unset fields of the overrides are inherited.
+	GasPricer          GasPricerConfig
+	GasPricerOverrides map[string]GasPricerConfig
 }
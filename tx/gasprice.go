@@ -0,0 +1,294 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Onther-Tech/plasma-evm/core/types"
+)
+
+// Gas pricing strategies
+const (
+	GasPricerFixed      = "fixed"      // configured gas price
+	GasPricerSuggest    = "suggest"    // eth_gasPrice of the root chain provider
+	GasPricerPercentile = "percentile" // percentile of gas prices in recent root chain blocks
+	GasPricerDeadline   = "deadline"   // suggested gas price, escalated faster as the deadline nears
+)
+
+var errUnknownGasPricer = errors.New("unknown gas pricing strategy")
+
+// GasPricerConfig is the configuration of a gas pricing strategy.
+type GasPricerConfig struct {
+	Strategy string
+
+	// Percentage added to the previous gas price of a stuck transaction.
+	Escalation uint64
+
+	// Percentile of gas prices in recent root chain blocks (percentile).
+	Percentile int
+
+	// Number of recent root chain blocks to sample (percentile), or number of
+	// root chain blocks in which a transaction should be mined (deadline).
+	Blocks uint64
+}
+
+var DefaultGasPricerConfig = GasPricerConfig{
+	Strategy:   GasPricerFixed,
+	Escalation: 20,
+	Percentile: 60,
+	Blocks:     20,
+}
+
+// merge fills the unset fields with the base configuration.
+func (c GasPricerConfig) merge(base GasPricerConfig) GasPricerConfig {
+	if c.Strategy == "" {
+		c.Strategy = base.Strategy
+	}
+	if c.Escalation == 0 {
+		c.Escalation = base.Escalation
+	}
+	if c.Percentile == 0 {
+		c.Percentile = base.Percentile
+	}
+	if c.Blocks == 0 {
+		c.Blocks = base.Blocks
+	}
+	return c
+}
+
+// ParseGasPricerOverrides parses comma separated gas pricers of caption kinds
+// in the form of kind=strategy[:escalation], e.g.
+// "submitORB=deadline:50,challengeExit=suggest:100".
+func ParseGasPricerOverrides(s string) (map[string]GasPricerConfig, error) {
+	overrides := make(map[string]GasPricerConfig)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid gas pricer override %q", item)
+		}
+
+		var c GasPricerConfig
+		fields := strings.SplitN(kv[1], ":", 2)
+		c.Strategy = fields[0]
+		if len(fields) == 2 {
+			escalation, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escalation of %s: %v", kv[0], err)
+			}
+			c.Escalation = escalation
+		}
+		overrides[kv[0]] = c
+	}
+	return overrides, nil
+}
+
+// PriceRequest is a raw transaction to be priced.
+type PriceRequest struct {
+	Kind        string   // caption kind, e.g. "submitORB"
+	Previous    *big.Int // gas price of the stuck transaction, nil for the first transaction
+	BlockNumber uint64   // current root chain block number
+	SentBlock   uint64   // root chain block number the raw transaction was sent first, zero if not sent
+	Deadline    uint64   // root chain block number by which the transaction should be mined, zero if none
+}
+
+// GasPricer decides gas prices of raw transactions.
+type GasPricer interface {
+	// GasPrice returns the gas price of the raw transaction. If the previous
+	// gas price is set, the returned price replaces the stuck transaction.
+	GasPrice(ctx context.Context, req *PriceRequest) (*big.Int, error)
+}
+
+// PriceBackend is the root chain provider used by gas pricers.
+type PriceBackend interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// NewGasPricer creates the gas pricer of the strategy. The fixed strategy
+// starts from the given gas price. Every strategy escalates faster as the
+// deadline of the raw transaction nears, and the deadline strategy gives the
+// raw transactions without one a deadline of Blocks after the first send.
+func NewGasPricer(config GasPricerConfig, backend PriceBackend, gasPrice *big.Int) (GasPricer, error) {
+	var (
+		base   GasPricer
+		blocks uint64 // blocks until the deadline of raw transactions without one
+	)
+	switch config.Strategy {
+	case GasPricerFixed:
+		base = &fixedPricer{gasPrice: new(big.Int).Set(gasPrice), escalation: config.Escalation}
+	case GasPricerSuggest:
+		base = &suggestPricer{backend: backend, escalation: config.Escalation}
+	case GasPricerPercentile:
+		if config.Percentile <= 0 || config.Percentile > 100 {
+			return nil, fmt.Errorf("invalid gas price percentile %d", config.Percentile)
+		}
+		if config.Blocks == 0 {
+			return nil, errors.New("number of blocks to sample gas prices is zero")
+		}
+		base = &percentilePricer{backend: backend, percentile: config.Percentile, blocks: config.Blocks, escalation: config.Escalation}
+	case GasPricerDeadline:
+		if config.Blocks == 0 {
+			return nil, errors.New("number of blocks until deadline is zero")
+		}
+		base = &suggestPricer{backend: backend, escalation: config.Escalation}
+		blocks = config.Blocks
+	default:
+		return nil, errUnknownGasPricer
+	}
+	return &deadlinePricer{base: base, blocks: blocks, escalation: config.Escalation}, nil
+}
+
+// escalate returns the previous gas price increased by the percentage.
+func escalate(previous *big.Int, percent uint64) *big.Int {
+	price := new(big.Int).Mul(previous, new(big.Int).SetUint64(100+percent))
+	return price.Div(price, big.NewInt(100))
+}
+
+// bigMax returns the larger one of a and b.
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return b
+	}
+	return a
+}
+
+// fixedPricer uses a fixed gas price, and escalates the price of the stuck
+// transaction.
+type fixedPricer struct {
+	gasPrice   *big.Int
+	escalation uint64
+}
+
+func (p *fixedPricer) GasPrice(ctx context.Context, req *PriceRequest) (*big.Int, error) {
+	if req.Previous == nil {
+		return new(big.Int).Set(p.gasPrice), nil
+	}
+	return escalate(req.Previous, p.escalation), nil
+}
+
+// suggestPricer uses the gas price suggested by the root chain provider.
+type suggestPricer struct {
+	backend    PriceBackend
+	escalation uint64
+}
+
+func (p *suggestPricer) GasPrice(ctx context.Context, req *PriceRequest) (*big.Int, error) {
+	price, err := p.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Previous == nil {
+		return price, nil
+	}
+	return bigMax(price, escalate(req.Previous, p.escalation)), nil
+}
+
+// percentilePricer uses a percentile of gas prices of the transactions in
+// recent root chain blocks.
+type percentilePricer struct {
+	backend    PriceBackend
+	percentile int
+	blocks     uint64
+	escalation uint64
+
+	lastHead  uint64
+	lastPrice *big.Int
+	lock      sync.Mutex
+}
+
+func (p *percentilePricer) GasPrice(ctx context.Context, req *PriceRequest) (*big.Int, error) {
+	price, err := p.sample(ctx, req.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if req.Previous == nil {
+		return price, nil
+	}
+	return bigMax(price, escalate(req.Previous, p.escalation)), nil
+}
+
+// sample returns the percentile of the gas prices in the recent blocks. The
+// result is cached until the root chain head changes.
+func (p *percentilePricer) sample(ctx context.Context, head uint64) (*big.Int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.lastPrice != nil && p.lastHead == head {
+		return new(big.Int).Set(p.lastPrice), nil
+	}
+
+	var prices []*big.Int
+	for i := uint64(0); i < p.blocks && i <= head; i++ {
+		block, err := p.backend.BlockByNumber(ctx, new(big.Int).SetUint64(head-i))
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range block.Transactions() {
+			prices = append(prices, tx.GasPrice())
+		}
+	}
+	if len(prices) == 0 {
+		return p.backend.SuggestGasPrice(ctx)
+	}
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+	price := prices[(len(prices)-1)*p.percentile/100]
+
+	p.lastHead, p.lastPrice = head, new(big.Int).Set(price)
+	return price, nil
+}
+
+// deadlinePricer escalates the price of the stuck transaction faster as its
+// deadline nears. Transactions without a deadline are priced by the base
+// pricer, unless blocks is set to give them one.
+type deadlinePricer struct {
+	base       GasPricer
+	blocks     uint64
+	escalation uint64
+}
+
+func (p *deadlinePricer) GasPrice(ctx context.Context, req *PriceRequest) (*big.Int, error) {
+	deadline := req.Deadline
+	if deadline == 0 && p.blocks != 0 && req.SentBlock != 0 {
+		deadline = req.SentBlock + p.blocks
+	}
+	if deadline == 0 {
+		return p.base.GasPrice(ctx, req)
+	}
+
+	price, err := p.base.GasPrice(ctx, &PriceRequest{Kind: req.Kind, BlockNumber: req.BlockNumber, Deadline: deadline})
+	if err != nil {
+		return nil, err
+	}
+	if req.Previous == nil {
+		return price, nil
+	}
+
+	// the escalation is multiplied by the blocks from the first send to the
+	// deadline over the blocks remaining
+	start := req.SentBlock
+	if start == 0 || start > req.BlockNumber {
+		start = req.BlockNumber
+	}
+	window, remaining := uint64(1), uint64(1)
+	if deadline > start {
+		window = deadline - start
+	}
+	if deadline > req.BlockNumber {
+		remaining = deadline - req.BlockNumber
+	}
+	if remaining > window {
+		remaining = window
+	}
+	return bigMax(price, escalate(req.Previous, p.escalation*window/remaining)), nil
+}
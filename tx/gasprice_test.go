@@ -0,0 +1,165 @@
+package tx
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+type testPriceBackend struct {
+	suggested *big.Int
+	blocks    map[uint64][]int64 // block number => gas prices of transactions
+}
+
+func (b *testPriceBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return b.suggested, nil
+}
+
+func (b *testPriceBackend) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var txs types.Transactions
+	for _, price := range b.blocks[number.Uint64()] {
+		txs = append(txs, types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(price), nil))
+	}
+	return types.NewBlock(&types.Header{Number: number}, txs, nil, nil), nil
+}
+
+// Tests that every strategy escalates the stuck transaction from its previous
+// gas price rather than from a global one.
+func TestGasPricerEscalation(t *testing.T) {
+	backend := &testPriceBackend{
+		suggested: big.NewInt(50),
+		blocks: map[uint64][]int64{
+			9:  {10, 20, 30},
+			10: {40, 50, 60, 70},
+		},
+	}
+
+	tests := []struct {
+		config      GasPricerConfig
+		first       int64
+		previous    int64
+		sentBlock   uint64
+		blockNumber uint64
+		escalated   int64
+	}{
+		{GasPricerConfig{Strategy: GasPricerFixed, Escalation: 20}, 100, 200, 0, 10, 240},
+		{GasPricerConfig{Strategy: GasPricerSuggest, Escalation: 20}, 50, 200, 0, 10, 240},
+		{GasPricerConfig{Strategy: GasPricerSuggest, Escalation: 20}, 50, 10, 0, 10, 50},
+		{GasPricerConfig{Strategy: GasPricerPercentile, Escalation: 20, Percentile: 50, Blocks: 2}, 40, 100, 0, 10, 120},
+		{GasPricerConfig{Strategy: GasPricerDeadline, Escalation: 10, Blocks: 10}, 50, 100, 10, 10, 110},
+		{GasPricerConfig{Strategy: GasPricerDeadline, Escalation: 10, Blocks: 10}, 50, 100, 5, 10, 120},
+		{GasPricerConfig{Strategy: GasPricerDeadline, Escalation: 10, Blocks: 10}, 50, 100, 1, 20, 200},
+	}
+	for i, tt := range tests {
+		pricer, err := NewGasPricer(tt.config, backend, big.NewInt(100))
+		if err != nil {
+			t.Fatalf("test %d: failed to create gas pricer: %v", i, err)
+		}
+
+		price, err := pricer.GasPrice(context.Background(), &PriceRequest{BlockNumber: tt.blockNumber})
+		if err != nil {
+			t.Fatalf("test %d: failed to price: %v", i, err)
+		}
+		if price.Int64() != tt.first {
+			t.Errorf("test %d: gas price mismatch: have %v, want %v", i, price, tt.first)
+		}
+
+		price, err = pricer.GasPrice(context.Background(), &PriceRequest{
+			Previous:    big.NewInt(tt.previous),
+			BlockNumber: tt.blockNumber,
+			SentBlock:   tt.sentBlock,
+		})
+		if err != nil {
+			t.Fatalf("test %d: failed to escalate: %v", i, err)
+		}
+		if price.Int64() != tt.escalated {
+			t.Errorf("test %d: escalated gas price mismatch: have %v, want %v", i, price, tt.escalated)
+		}
+	}
+}
+
+// Tests that the stuck transaction with a deadline is escalated faster as the
+// deadline nears regardless of the strategy.
+func TestGasPricerDeadline(t *testing.T) {
+	pricer, err := NewGasPricer(GasPricerConfig{Strategy: GasPricerFixed, Escalation: 10}, &testPriceBackend{}, big.NewInt(100))
+	if err != nil {
+		t.Fatalf("failed to create gas pricer: %v", err)
+	}
+
+	tests := []struct {
+		blockNumber uint64
+		escalated   int64
+	}{
+		{10, 110}, // 10 blocks remaining
+		{15, 120}, // 5 blocks remaining
+		{19, 200}, // 1 block remaining
+		{25, 200}, // deadline missed
+	}
+	for i, tt := range tests {
+		price, err := pricer.GasPrice(context.Background(), &PriceRequest{
+			Previous:    big.NewInt(100),
+			BlockNumber: tt.blockNumber,
+			SentBlock:   10,
+			Deadline:    20,
+		})
+		if err != nil {
+			t.Fatalf("test %d: failed to escalate: %v", i, err)
+		}
+		if price.Int64() != tt.escalated {
+			t.Errorf("test %d: escalated gas price mismatch: have %v, want %v", i, price, tt.escalated)
+		}
+	}
+}
+
+func TestParseGasPricerOverrides(t *testing.T) {
+	overrides, err := ParseGasPricerOverrides("submitORB=deadline:50, challengeExit=suggest")
+	if err != nil {
+		t.Fatalf("failed to parse overrides: %v", err)
+	}
+	if c := overrides["submitORB"]; c.Strategy != GasPricerDeadline || c.Escalation != 50 {
+		t.Errorf("submitORB override mismatch: %+v", c)
+	}
+	if c := overrides["challengeExit"].merge(DefaultGasPricerConfig); c.Strategy != GasPricerSuggest || c.Escalation != DefaultGasPricerConfig.Escalation {
+		t.Errorf("challengeExit override mismatch: %+v", c)
+	}
+	if _, err := ParseGasPricerOverrides("submitORB"); err == nil {
+		t.Errorf("invalid override is parsed")
+	}
+}
+
+// Tests that the fixed gas pricer starts from the configured gas price rather
+// than a stored one, and that escalating a stuck transaction does not change
+// the price of the other transactions.
+func TestFixedGasPriceSeed(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	WriteGasPrice(db, big.NewInt(90*params.GWei))
+
+	config := *DefaultConfig
+	config.GasPrice = big.NewInt(5 * params.GWei)
+
+	tm, err := NewTransactionManager(nil, nil, db, &config)
+	if err != nil {
+		t.Fatalf("failed to create transaction manager: %v", err)
+	}
+
+	to := common.HexToAddress("0x02")
+	stuck := NewRawTransaction(common.HexToAddress("0x01"), 21000, &to, big.NewInt(0), []byte{1}, false, "stuck")
+	if price := tm.gasPriceOf(stuck); price.Int64() != 5*params.GWei {
+		t.Fatalf("gas price mismatch: have %v, want %v", price, 5*params.GWei)
+	}
+
+	tm.adjustGasPrice(stuck)
+	if price := stuck.gasPrice; price.Int64() != 6*params.GWei {
+		t.Fatalf("escalated gas price mismatch: have %v, want %v", price, 6*params.GWei)
+	}
+
+	next := NewRawTransaction(common.HexToAddress("0x01"), 21000, &to, big.NewInt(0), []byte{2}, false, "next")
+	if price := tm.gasPriceOf(next); price.Int64() != 5*params.GWei {
+		t.Fatalf("gas price mismatch: have %v, want %v", price, 5*params.GWei)
+	}
+}
@@ -12,12 +12,12 @@ import (
 
 	"github.com/Onther-Tech/plasma-evm"
 	"github.com/Onther-Tech/plasma-evm/accounts"
-	"github.com/Onther-Tech/plasma-evm/accounts/keystore"
 	"github.com/Onther-Tech/plasma-evm/common"
 	"github.com/Onther-Tech/plasma-evm/core"
 	"github.com/Onther-Tech/plasma-evm/core/types"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
 	"github.com/Onther-Tech/plasma-evm/ethdb"
+	"github.com/Onther-Tech/plasma-evm/event"
 	"github.com/Onther-Tech/plasma-evm/log"
 	"github.com/Onther-Tech/plasma-evm/params"
 )
@@ -32,24 +32,33 @@ const (
 	ConfirmationDelay = 4
 )
 
+// headPollInterval is the interval between polls of the root chain head.
+const headPollInterval = 3 * time.Second
+
 var (
 	ErrLockedAccount    = errors.New("account is locked")
-	ErrUnknownAccount   = errors.New("account not found in keystore")
+	ErrUnknownAccount   = errors.New("account not found in account manager")
 	ErrKnownTransaction = errors.New("known transaction")
 	ErrDuplicateRaw     = errors.New("duplicate raw transaction")
 	ErrNoDuplicateRaw   = errors.New("there is no duplicate raw transaction")
+	ErrUnknownRaw       = errors.New("raw transaction is not queued")
+	ErrMinedRaw         = errors.New("raw transaction is already mined")
+	ErrNonceTooLow      = errors.New("nonce too low")
 )
 
 // TODO: Add JSONRPC API for TransactionManager
 type TransactionManager struct {
 	config *Config
 
-	ks      *keystore.KeyStore
-	backend *ethclient.Client
+	am      *accounts.Manager
+	backend *failover.Client
 	db      ethdb.Database
 
 	currentBlockNumber *big.Int // current block number of root chian network
-	gasPrice           *big.Int
+	gasPrice           *big.Int // configured gas price, used if a gas pricer fails
+
+	pricer  GasPricer            // default gas pricer
+	pricers map[string]GasPricer // caption kind => gas pricer
 
 	addresses []common.Address // list of account address
 
@@ -57,24 +66,35 @@ type TransactionManager struct {
 	unconfirmed map[common.Address]RawTransactions // mined but not confirmed raw transactions
 	pending     map[common.Address]RawTransactions // raw transactions to be sent
 
-	nonce map[common.Address]uint64 // account nonce
+	nonce        map[common.Address]uint64       // account nonce
+	nonceReports map[common.Address]*NonceReport // last nonce reconciliation
+	reconcileCh  chan common.Address
 
 	lastInspectTime time.Time
 
 	numKnownErr map[common.Hash]uint64 // number of know transaction error
 
+	sendGuard func() error // checked before a transaction is sent, nil to always send
+
 	taskCh chan *RawTransaction
 
+	deadlineFeed  event.Feed
+	confirmedFeed event.Feed
+	scope         event.SubscriptionScope
+
 	lock         sync.RWMutex
 	gasPriceLock sync.Mutex
 	quit         chan struct{}
 }
 
-func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db ethdb.Database, config *Config) (*TransactionManager, error) {
+// NewTransactionManager creates a transaction manager signing raw transactions
+// with the wallets of the account manager, which may be local keystores or an
+// external signer.
+func NewTransactionManager(am *accounts.Manager, backend *failover.Client, db ethdb.Database, config *Config) (*TransactionManager, error) {
 	tm := &TransactionManager{
 		config: config,
 
-		ks:      ks,
+		am:      am,
 		db:      db,
 		backend: backend,
 
@@ -85,7 +105,9 @@ func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db
 		unconfirmed: make(map[common.Address]RawTransactions),
 		pending:     make(map[common.Address]RawTransactions),
 
-		nonce: make(map[common.Address]uint64),
+		nonce:        make(map[common.Address]uint64),
+		nonceReports: make(map[common.Address]*NonceReport),
+		reconcileCh:  make(chan common.Address, 1),
 
 		numKnownErr: make(map[common.Hash]uint64),
 
@@ -94,13 +116,12 @@ func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db
 		quit: make(chan struct{}),
 	}
 
-	gasPrice := ReadGasPrice(db)
-
 	if config.MinGasPrice.Cmp(config.MaxGasPrice) > 0 {
 		return nil, errors.New("min gas price cannot exceed max gas price")
 	}
 
-	if config.GasPrice.Cmp(big.NewInt(0)) == 0 {
+	gasPrice := new(big.Int).Set(config.GasPrice)
+	if gasPrice.Sign() == 0 {
 		gasPrice = new(big.Int).Set(DefaultConfig.GasPrice)
 		log.Info("Use default gas price", "gasprice", gasPrice)
 	}
@@ -117,10 +138,37 @@ func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db
 
 	tm.gasPrice = gasPrice
 
+	var err error
+	if tm.pricer, err = NewGasPricer(config.GasPricer.merge(DefaultGasPricerConfig), backend, gasPrice); err != nil {
+		return nil, err
+	}
+	tm.pricers = make(map[string]GasPricer)
+	for kind, c := range config.GasPricerOverrides {
+		if tm.pricers[kind], err = NewGasPricer(c.merge(config.GasPricer).merge(DefaultGasPricerConfig), backend, gasPrice); err != nil {
+			return nil, fmt.Errorf("failed to create gas pricer of %s: %v", kind, err)
+		}
+	}
+
+	if err := tm.load(); err != nil {
+		return nil, err
+	}
+
+	tm.updateQueueMetrics()
+	updateGasPriceMetrics(tm.gasPrice)
+
+	log.Info("Transaction manager loaded", "numAccounts", len(tm.addresses))
+
+	return tm, nil
+}
+
+// load reads accounts and their raw transactions from the database.
+func (tm *TransactionManager) load() error {
+	db, backend := tm.db, tm.backend
+
 	numAddrs := ReadNumAddr(db)
 
 	if numAddrs == MaxUint64 {
-		return nil, errors.New("failed to read account number in database")
+		return errors.New("failed to read account number in database")
 	}
 
 	var (
@@ -139,7 +187,7 @@ func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db
 
 		numConfirmedRawTxs := ReadNumConfirmedRawTxs(tm.db, addr)
 		if numConfirmedRawTxs == MaxUint64 {
-			return nil, errors.New(fmt.Sprintf("failed to read number of confirmed raw transaction of %s", addr.String()))
+			return errors.New(fmt.Sprintf("failed to read number of confirmed raw transaction of %s", addr.String()))
 		}
 
 		log.Info("Previous account loaded", "addr", addr, "numConfirmedRawTxs", numConfirmedRawTxs)
@@ -152,13 +200,18 @@ func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db
 
 		tm.unconfirmed[addr] = ReadUnconfirmedTxs(tm.db, addr)
 		tm.pending[addr] = ReadPendingTxs(tm.db, addr)
+		for _, raws := range []RawTransactions{tm.unconfirmed[addr], tm.pending[addr]} {
+			for _, raw := range raws {
+				raw.Deadline = ReadRawTxDeadline(db, addr, raw.Hash())
+			}
+		}
 
 		tm.nonce[addr] = ReadAddrNonce(db, addr)
 		if tm.nonce[addr] == 0 {
 			tm.nonce[addr], err = backend.NonceAt(context.Background(), addr, nil)
 			if err != nil {
 				log.Error("Failed to read account nonce", "err", err)
-				return nil, err
+				return err
 			}
 			WriteAddrNonce(db, addr, tm.nonce[addr])
 		}
@@ -166,9 +219,31 @@ func NewTransactionManager(ks *keystore.KeyStore, backend *ethclient.Client, db
 		tm.inspect(addr)
 	}
 
-	log.Info("Transaction manager loaded", "numAccounts", numAddrs)
+	return nil
+}
 
-	return tm, nil
+// Reload discards accounts and raw transactions in memory and reads them from
+// the database again. It is used by a standby operator which replicated the
+// database of the primary, and must be called before Start.
+func (tm *TransactionManager) Reload() error {
+	tm.lock.Lock()
+	defer tm.lock.Unlock()
+
+	tm.addresses = nil
+	tm.confirmed = make(map[common.Address]RawTransactions)
+	tm.unconfirmed = make(map[common.Address]RawTransactions)
+	tm.pending = make(map[common.Address]RawTransactions)
+	tm.nonce = make(map[common.Address]uint64)
+	tm.nonceReports = make(map[common.Address]*NonceReport)
+
+	if err := tm.load(); err != nil {
+		return err
+	}
+
+	tm.updateQueueMetrics()
+
+	log.Info("Transaction manager reloaded", "numAccounts", len(tm.addresses))
+	return nil
 }
 
 // Add adds raw transaction to confirmed.
@@ -180,7 +255,7 @@ func (tm *TransactionManager) Add(account accounts.Account, raw *RawTransaction,
 
 	tm.inspect(addr)
 
-	if !tm.ks.HasAddress(addr) {
+	if _, err := tm.am.Find(account); err != nil {
 		return ErrUnknownAccount
 	}
 
@@ -206,7 +281,7 @@ func (tm *TransactionManager) Add(account accounts.Account, raw *RawTransaction,
 		if previous := ReadRawTxHash(tm.db, addr, raw.Hash()); previous != nil {
 			return ErrDuplicateRaw
 		}
-		WriteRawTxHash(tm.db, addr, *raw)
+		WriteRawTxHash(tm.db, addr, raw)
 	} else {
 		// add duplicate raw transaction
 
@@ -215,6 +290,16 @@ func (tm *TransactionManager) Add(account accounts.Account, raw *RawTransaction,
 		}
 	}
 
+	tm.enqueue(addr, raw)
+
+	log.Info("Raw transaction added", "caption", raw.getCaption(), "from", raw.From)
+
+	return nil
+}
+
+// enqueue assigns the next index and nonce of the account to the raw
+// transaction and appends it to the pending queue. tm.lock must be held.
+func (tm *TransactionManager) enqueue(addr common.Address, raw *RawTransaction) {
 	// assign index
 	i := ReadNumRawTxs(tm.db, addr)
 	raw.Index = i
@@ -225,15 +310,152 @@ func (tm *TransactionManager) Add(account accounts.Account, raw *RawTransaction,
 	tm.nonce[addr]++
 	WriteAddrNonce(tm.db, addr, tm.nonce[addr])
 
+	if raw.Deadline != 0 {
+		WriteRawTxDeadline(tm.db, addr, raw.Hash(), raw.Deadline)
+	}
+
 	// enqueue raw transaction
+	raw.addedAt = time.Now()
 	tm.pending[addr] = append(tm.pending[addr], raw)
 	WritePendingTxs(tm.db, addr, tm.pending[addr])
+	tm.updateQueueMetrics()
+}
 
-	log.Info("Raw transaction added", "caption", raw.getCaption(), "from", raw.From)
+// Cancel cancels the pending raw transaction by replacing it with a 0-value
+// transfer to the sender itself at the same nonce.
+func (tm *TransactionManager) Cancel(raw *RawTransaction) error {
+	from := raw.From
+	cancel := NewRawTransaction(from, params.TxGas, &from, big.NewInt(0), nil, false, "")
+
+	return tm.replace(raw, cancel, true)
+}
+
+// Replace replaces the payload of the pending raw transaction with the one of
+// the replacement. The raw transaction keeps its index and nonce, so the order
+// of the raw transactions from the account is kept. The replacement itself is
+// not queued. If a transaction sent before the replacement is mined, the
+// replacement is queued again at a new nonce.
+func (tm *TransactionManager) Replace(raw, replacement *RawTransaction) error {
+	return tm.replace(raw, replacement, false)
+}
+
+func (tm *TransactionManager) replace(raw, replacement *RawTransaction, cancel bool) error {
+	addr := raw.From
+
+	tm.lock.RLock()
+	queued, err := tm.pendingRaw(addr, raw.Index)
+	tm.lock.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	// wait until the raw transaction is not being sent
+	queued.sendLock.Lock()
+	defer queued.sendLock.Unlock()
+
+	// the root chain is called without tm.lock
+	if mined, err := queued.CheckMined(tm.backend, false); err != nil {
+		return err
+	} else if mined {
+		return ErrMinedRaw
+	}
+
+	previousCaption, err := func() (string, error) {
+		tm.lock.Lock()
+		defer tm.lock.Unlock()
+
+		if current, err := tm.pendingRaw(addr, raw.Index); err != nil {
+			return "", err
+		} else if current != queued {
+			return "", ErrUnknownRaw
+		}
+
+		if cancel {
+			replacement.Caption = fmt.Sprintf("cancel(%s)", queued.Caption)
+		} else {
+			if previous := ReadRawTxHash(tm.db, addr, replacement.Hash()); previous != nil {
+				return "", ErrDuplicateRaw
+			}
+			WriteRawTxHash(tm.db, addr, replacement)
+		}
+
+		// the replaced payload can be added again unless it is queued as duplicate
+		previousHash, previousCaption := queued.Hash(), queued.getCaption()
+		if !tm.hasRaw(addr, previousHash, queued) {
+			DeleteRawTxHash(tm.db, addr, previousHash)
+		}
+		DeleteRawTxDeadline(tm.db, addr, previousHash)
+
+		queued.replaceWith(replacement)
+		if queued.Deadline != 0 {
+			WriteRawTxDeadline(tm.db, addr, queued.Hash(), queued.Deadline)
+		}
+		WritePendingTxs(tm.db, addr, tm.pending[addr])
+
+		return previousCaption, nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	// the replacement must outbid the transactions sent at the nonce
+	if len(queued.PendingTxs) > 0 {
+		tm.adjustGasPrice(queued)
+	}
+
+	if cancel {
+		cancelledMeter.Mark(1)
+	} else {
+		replacedMeter.Mark(1)
+	}
+	log.Info("Raw transaction replaced", "previous", previousCaption, "caption", queued.getCaption(), "nonce", queued.Nonce, "from", addr)
 
 	return nil
 }
 
+// pendingRaw returns the pending raw transaction of the index.
+// tm.lock must be held.
+func (tm *TransactionManager) pendingRaw(addr common.Address, index uint64) (*RawTransaction, error) {
+	for _, raw := range tm.pending[addr] {
+		if raw.Index == index {
+			return raw, nil
+		}
+	}
+	for _, raw := range tm.unconfirmed[addr] {
+		if raw.Index == index {
+			return nil, ErrMinedRaw
+		}
+	}
+	return nil, ErrUnknownRaw
+}
+
+// hasRaw returns whether a raw transaction other than the given one has the
+// hash. tm.lock must be held.
+func (tm *TransactionManager) hasRaw(addr common.Address, hash common.Hash, except *RawTransaction) bool {
+	for _, raws := range []RawTransactions{tm.confirmed[addr], tm.unconfirmed[addr], tm.pending[addr]} {
+		for _, raw := range raws {
+			if raw != except && raw.Hash() == hash {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasUnconfirmedRaw returns whether a pending or unconfirmed raw transaction
+// other than the given one has the hash. tm.lock must be held.
+func (tm *TransactionManager) hasUnconfirmedRaw(addr common.Address, hash common.Hash, except *RawTransaction) bool {
+	for _, raws := range []RawTransactions{tm.unconfirmed[addr], tm.pending[addr]} {
+		for _, raw := range raws {
+			if raw != except && raw.Hash() == hash {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // TODO: rename to Has
 // Count returns the number of raw transactions corresponding to the transaction.
 func (tm *TransactionManager) Count(account accounts.Account, tx *types.Transaction) uint64 {
@@ -256,8 +478,40 @@ func (tm *TransactionManager) Count(account accounts.Account, tx *types.Transact
 	return count
 }
 
+// NumPending returns the number of raw transactions of the account which are not mined yet.
+func (tm *TransactionManager) NumPending(addr common.Address) int {
+	tm.lock.RLock()
+	defer tm.lock.RUnlock()
+
+	return len(tm.pending[addr])
+}
+
+// PendingRaws returns the raw transactions of the account which are not mined yet.
+func (tm *TransactionManager) PendingRaws(addr common.Address) RawTransactions {
+	tm.lock.RLock()
+	defer tm.lock.RUnlock()
+
+	return append(RawTransactions{}, tm.pending[addr]...)
+}
+
+// SetSendGuard sets the check made before every transaction is sent or resent
+// to the root chain. Transactions are not sent while the check fails. It must
+// be called before Start.
+func (tm *TransactionManager) SetSendGuard(guard func() error) {
+	tm.sendGuard = guard
+}
+
+// checkSendGuard returns the error of the send guard if it is set.
+func (tm *TransactionManager) checkSendGuard() error {
+	if tm.sendGuard == nil {
+		return nil
+	}
+	return tm.sendGuard()
+}
+
 func (tm *TransactionManager) Start() {
 	go tm.confirmLoop()
+	go tm.nonceLoop()
 
 	// send a single raw transaction to root chain.
 	// TODO: make it safe under root chain provider disconnect
@@ -270,31 +524,6 @@ func (tm *TransactionManager) Start() {
 			return raw.MinedTxHash, nil
 		}
 
-		// subscribe new block mined event
-		newHeaderEvents := make(chan *types.Header)
-		newHeaderSub, err := tm.backend.SubscribeNewHead(context.Background(), newHeaderEvents)
-
-		close := func() {
-			defer func() {
-				if err := recover(); err != nil {
-					log.Error("New block event unsubscription", "err", err)
-				}
-			}()
-			newHeaderSub.Unsubscribe()
-		}
-
-		defer close()
-
-		if err != nil {
-			log.Error("Failed to subscribe new block event", "err", err)
-		}
-
-		clearHeaderEvent := func() {
-			for len(newHeaderEvents) > 0 {
-				<-newHeaderEvents
-			}
-		}
-
 		// account to send transaction
 		from := accounts.Account{Address: addr}
 
@@ -311,15 +540,23 @@ func (tm *TransactionManager) Start() {
 				return common.Hash{}, nil
 			}
 
-			tm.gasPriceLock.Lock()
-			tx := raw.ToTransaction(tm.gasPrice)
-			tm.gasPriceLock.Unlock()
+			if err := tm.checkSendGuard(); err != nil {
+				return common.Hash{}, err
+			}
 
-			signedTx, err := tm.ks.SignTx(from, tx, tm.config.ChainId)
+			tx := raw.ToTransaction(tm.gasPriceOf(raw))
+
+			wallet, err := tm.am.Find(from)
+			if err != nil {
+				log.Error("failed to find wallet", "err", err, "from", from.Address)
+				return common.Hash{}, err
+			}
+
+			signedTx, err := wallet.SignTx(from, tx, tm.config.ChainId)
 
 			if err != nil {
 				log.Error("failed to sign transaction", "err", err, "raw", raw.Hash(), "caption", raw.getCaption(), "tx", tx.Hash())
-				return signedTx.Hash(), err
+				return common.Hash{}, err
 			}
 
 			// short circuit raw transaction already has same transaction.
@@ -327,12 +564,19 @@ func (tm *TransactionManager) Start() {
 				return signedTx.Hash(), nil
 			}
 
+			if len(raw.PendingTxs) > 0 {
+				resendMeter.Mark(1)
+			}
+
 			err = raw.AddPending(signedTx)
 			if err != nil {
 				log.Error(err.Error(), "raw", raw.Hash(), "caption", raw.getCaption(), "tx", tx.Hash())
 				return signedTx.Hash(), err
 			}
 			raw.LastSentBlockNumber = blockNumber
+			if raw.firstSentBlock == 0 {
+				raw.firstSentBlock = blockNumber
+			}
 
 			tm.lock.Lock()
 			WritePendingTxs(tm.db, addr, tm.pending[addr])
@@ -341,6 +585,7 @@ func (tm *TransactionManager) Start() {
 			err = tm.backend.SendTransaction(context.Background(), signedTx)
 
 			if err == nil {
+				sentMeter.Mark(1)
 				log.Info("Transaction sent", "hash", signedTx.Hash(), "nonce", raw.Nonce, "caption", raw.getCaption(), "gasprice", signedTx.GasPrice())
 				return signedTx.Hash(), nil
 			}
@@ -369,36 +614,18 @@ func (tm *TransactionManager) Start() {
 					return signedTx.Hash(), ErrKnownTransaction
 				}
 
-				clearHeaderEvent()
-
-				select {
-				case <-newHeaderEvents:
-					return signedTx.Hash(), ErrKnownTransaction
-				case <-tm.quit:
+				// wait for the next root chain block
+				if !tm.waitNextBlock(blockNumber) {
 					return signedTx.Hash(), nil
 				}
-
+				return signedTx.Hash(), ErrKnownTransaction
 			}
 
-			// resubmit transaction with nonce increased.
+			// renumber the queue once the transaction is not being sent.
 			if strings.Contains(errMessage, "nonce too low") || strings.Contains(errMessage, "nonce is too low") {
-				// increase nonce immediately
-				previousNonce := raw.Nonce.Uint64()
-
-				tm.nonce[addr], err = tm.backend.NonceAt(context.Background(), addr, nil)
-
-				if err != nil {
-					log.Error("Failed to read account nonce", "err", err)
-				} else {
-					log.Warn("Account nonce has increased by another transaction", "previousNonce", previousNonce, "currentNonce", tm.nonce[addr])
-					raw.Nonce = big.NewInt(int64(tm.nonce[addr]))
-					WriteAddrNonce(tm.db, addr, tm.nonce[addr])
-				}
-				return f()
-
-				// if more than 1 transactions are pending, increase nonce carefully.
-				// TODO: count and increase nonce
-				return signedTx.Hash(), nil
+				log.Warn("Account nonce has increased by another transaction", "nonce", raw.Nonce, "caption", raw.getCaption())
+				tm.requestReconcile(addr)
+				return signedTx.Hash(), ErrNonceTooLow
 			}
 
 			// return unknown error
@@ -432,6 +659,10 @@ func (tm *TransactionManager) Start() {
 							return
 						}
 
+						for _, pending := range queue {
+							tm.checkDeadline(pending)
+						}
+
 						var raw *RawTransaction
 
 						// find next pending raw transaction
@@ -447,16 +678,32 @@ func (tm *TransactionManager) Start() {
 							return
 						}
 
+						// short circuit if sending is not allowed
+						if err := tm.checkSendGuard(); err != nil {
+							log.Warn("Transactions are not sent", "addr", addr, "err", err)
+							return
+						}
+
 						hash, err := send(addr, raw)
 
+						// send the following raw transactions without waiting for the first one to be mined
+						if err == nil {
+							tm.sendAhead(addr, queue, raw, send)
+						}
+
 						// resubmit transaction in pending intarval loop
 						if err == core.ErrReplaceUnderpriced {
 							log.Debug("Gas price is fixed for underpriced transaction error")
-							tm.adjustGasPrice(raw, false)
+							tm.adjustGasPrice(raw)
 							hash, err = send(addr, raw)
 							return
 						}
 
+						// wait until the nonces are reconciled
+						if err == ErrNonceTooLow {
+							return
+						}
+
 						// short circuit if operator has not enough fund.
 						if err == core.ErrInsufficientFunds || err == core.ErrReplaceUnderpriced {
 							log.Error("Account doesn't have enough fund to run the chain.", "addr", addr)
@@ -488,12 +735,12 @@ func (tm *TransactionManager) Start() {
 						if err2 == ethereum.NotFound {
 							log.Warn("Ethereum Transaction not found. It may be pending", "err", err2, "caption", raw.getCaption(), "hash", hash.Hex())
 							fixed = true
-							tm.adjustGasPrice(raw, false)
+							tm.adjustGasPrice(raw)
 						}
 
 						if err != nil && !fixed {
 							log.Debug("Unknown transaction error", "err", err)
-							tm.adjustGasPrice(raw, false)
+							tm.adjustGasPrice(raw)
 						}
 
 						hash, err = send(addr, raw)
@@ -512,54 +759,187 @@ func (tm *TransactionManager) Start() {
 	}()
 }
 
-// adjustGasPrice adjust gas prices at a reasonable price.
-func (tm *TransactionManager) adjustGasPrice(raw *RawTransaction, decrease bool) {
-	tm.gasPriceLock.Lock()
-	defer tm.gasPriceLock.Unlock()
+// sendAhead sends the unsent ORB submissions following the first unmined raw
+// transaction at their sequential nonces, at most config.Pipeline of them. It
+// stops at the first raw transaction of another kind. They are resent with
+// adjusted gas prices once they become the first unmined one.
+func (tm *TransactionManager) sendAhead(addr common.Address, queue RawTransactions, head *RawTransaction, send func(common.Address, *RawTransaction) (common.Hash, error)) {
+	var (
+		ahead int
+		next  = head.Nonce.Uint64() + 1
+	)
+	for _, raw := range queue {
+		if ahead >= tm.config.Pipeline {
+			return
+		}
+		if raw == head || raw.Mined(tm.backend) || raw.Nonce.Uint64() < next {
+			continue
+		}
+		// a gap in the nonces would stall the following transactions
+		if raw.Nonce.Uint64() != next || captionKind(raw.Caption) != "submitORB" {
+			return
+		}
+		next++
+		ahead++
 
-	var previousGasPrice *big.Int
+		raw.lock.RLock()
+		sent := len(raw.PendingTxs) > 0
+		raw.lock.RUnlock()
+		if sent {
+			continue
+		}
 
-	if (raw.MinedTxHash != common.Hash{}) {
-		tx, isPending, err := tm.backend.TransactionByHash(context.Background(), raw.MinedTxHash)
-		if isPending || err != nil {
+		if _, err := send(addr, raw); err != nil {
+			log.Debug("Failed to send transaction ahead", "caption", raw.getCaption(), "nonce", raw.Nonce, "err", err)
 			return
 		}
-		previousGasPrice = tx.GasPrice()
-	} else if len(raw.PendingTxs) == 0 {
-		previousGasPrice = new(big.Int).Set(tm.gasPrice)
-	} else {
-		lastPendingTx := raw.PendingTxs[len(raw.PendingTxs)-1]
-		previousGasPrice = new(big.Int).Set(lastPendingTx.GasPrice())
+		pipelinedMeter.Mark(1)
 	}
+}
 
-	gasPrice := new(big.Int).Set(previousGasPrice)
+// pricerOf returns the gas pricer of the raw transaction.
+func (tm *TransactionManager) pricerOf(raw *RawTransaction) GasPricer {
+	if pricer, ok := tm.pricers[captionKind(raw.Caption)]; ok {
+		return pricer
+	}
+	return tm.pricer
+}
 
-	if decrease {
-		// new gas price = previous gas price * 0.4
-		gasPrice = new(big.Int).Mul(new(big.Int).Div(tm.gasPrice, big.NewInt(10)), big.NewInt(4))
-	} else {
-		// new gas price = previous gas price * 1.2
-		gasPrice = new(big.Int).Mul(new(big.Int).Div(tm.gasPrice, big.NewInt(10)), big.NewInt(12))
+// priceRequest returns the pricing request of the raw transaction.
+func (tm *TransactionManager) priceRequest(raw *RawTransaction, previous *big.Int) *PriceRequest {
+	sentBlock := raw.firstSentBlock
+	if sentBlock == 0 {
+		sentBlock = raw.LastSentBlockNumber
 	}
+	return &PriceRequest{
+		Kind:        captionKind(raw.Caption),
+		Previous:    previous,
+		BlockNumber: tm.currentBlockNumber.Uint64(),
+		SentBlock:   sentBlock,
+		Deadline:    raw.Deadline,
+	}
+}
+
+// SubscribeDeadlineMissed registers a subscription of DeadlineMissedEvent.
+func (tm *TransactionManager) SubscribeDeadlineMissed(ch chan<- DeadlineMissedEvent) event.Subscription {
+	return tm.scope.Track(tm.deadlineFeed.Subscribe(ch))
+}
+
+// SubscribeConfirmed registers a subscription of ConfirmedEvent.
+func (tm *TransactionManager) SubscribeConfirmed(ch chan<- ConfirmedEvent) event.Subscription {
+	return tm.scope.Track(tm.confirmedFeed.Subscribe(ch))
+}
 
-	// make target gas price in range
+// confirmedEvent returns the event of the confirmed raw transaction.
+func confirmedEvent(raw *RawTransaction) ConfirmedEvent {
+	raw.lock.RLock()
+	defer raw.lock.RUnlock()
+
+	return ConfirmedEvent{Raw: raw, GasUsed: raw.gasUsed, GasPrice: raw.minedGasPrice}
+}
+
+// checkDeadline alerts once if the raw transaction is not mined by its
+// deadline.
+func (tm *TransactionManager) checkDeadline(raw *RawTransaction) {
+	if raw.Deadline == 0 || raw.deadlineMissed || (raw.MinedTxHash != common.Hash{}) {
+		return
+	}
+
+	blockNumber := tm.currentBlockNumber.Uint64()
+	if blockNumber <= raw.Deadline {
+		return
+	}
+	raw.deadlineMissed = true
+
+	deadlineMissedMeter.Mark(1)
+	log.Error("Transaction deadline missed", "caption", raw.getCaption(), "from", raw.From, "nonce", raw.Nonce, "deadline", raw.Deadline, "blockNumber", blockNumber)
+	tm.deadlineFeed.Send(DeadlineMissedEvent{Raw: raw, BlockNumber: blockNumber})
+}
+
+// clampGasPrice returns the gas price in the configured range.
+func (tm *TransactionManager) clampGasPrice(gasPrice *big.Int) *big.Int {
+	gasPrice = new(big.Int).Set(gasPrice)
 	if gasPrice.Cmp(tm.config.MinGasPrice) < 0 {
 		gasPrice.Set(tm.config.MinGasPrice)
 	}
 	if gasPrice.Cmp(tm.config.MaxGasPrice) > 0 {
 		gasPrice.Set(tm.config.MaxGasPrice)
 	}
+	return gasPrice
+}
 
-	tm.gasPrice = new(big.Int).Set(gasPrice)
+// gasPriceOf returns the gas price to sign the raw transaction with. The raw
+// transaction keeps its gas price until it is adjusted.
+func (tm *TransactionManager) gasPriceOf(raw *RawTransaction) *big.Int {
+	tm.gasPriceLock.Lock()
+	if raw.gasPrice != nil {
+		defer tm.gasPriceLock.Unlock()
+		return raw.gasPrice
+	}
+	if len(raw.PendingTxs) > 0 {
+		defer tm.gasPriceLock.Unlock()
+		raw.gasPrice = raw.PendingTxs[len(raw.PendingTxs)-1].GasPrice()
+		return raw.gasPrice
+	}
+	tm.gasPriceLock.Unlock()
 
-	WriteGasPrice(tm.db, gasPrice)
+	// the gas pricer may call the root chain, so it is called without the lock
+	gasPrice, err := tm.pricerOf(raw).GasPrice(context.Background(), tm.priceRequest(raw, nil))
+	if err != nil {
+		log.Warn("Failed to price transaction, use the configured gas price", "caption", raw.getCaption(), "err", err)
+		gasPrice = tm.gasPrice
+	}
 
-	previousGwei := gasPriceToString(previousGasPrice)
-	adjustGwei := gasPriceToString(tm.gasPrice)
+	tm.gasPriceLock.Lock()
+	defer tm.gasPriceLock.Unlock()
 
-	log.Info("Gas price adjusted", "caption", raw.getCaption(), "decrease", decrease,
-		"previous", previousGwei,
-		"adjusted", adjustGwei)
+	// keep the gas price set while the pricer was called
+	if raw.gasPrice == nil {
+		raw.gasPrice = tm.clampGasPrice(gasPrice)
+		updateGasPriceMetrics(raw.gasPrice)
+	}
+	return raw.gasPrice
+}
+
+// adjustGasPrice escalates the gas price of the stuck raw transaction from its
+// previous gas price.
+func (tm *TransactionManager) adjustGasPrice(raw *RawTransaction) {
+	// short circuit if already mined
+	if (raw.MinedTxHash != common.Hash{}) {
+		return
+	}
+
+	tm.gasPriceLock.Lock()
+	var previousGasPrice *big.Int
+	if raw.gasPrice != nil {
+		previousGasPrice = new(big.Int).Set(raw.gasPrice)
+	} else if len(raw.PendingTxs) > 0 {
+		previousGasPrice = new(big.Int).Set(raw.PendingTxs[len(raw.PendingTxs)-1].GasPrice())
+	} else {
+		previousGasPrice = new(big.Int).Set(tm.gasPrice)
+	}
+	tm.gasPriceLock.Unlock()
+
+	// the gas pricer may call the root chain, so it is called without the lock
+	gasPrice, err := tm.pricerOf(raw).GasPrice(context.Background(), tm.priceRequest(raw, previousGasPrice))
+	if err != nil {
+		log.Warn("Failed to price stuck transaction, keep the gas price", "caption", raw.getCaption(), "err", err)
+		gasPrice = previousGasPrice
+	}
+	gasPrice = tm.clampGasPrice(gasPrice)
+
+	tm.gasPriceLock.Lock()
+	// never lower the gas price adjusted while the pricer was called
+	if raw.gasPrice == nil || raw.gasPrice.Cmp(gasPrice) < 0 {
+		raw.gasPrice = gasPrice
+	}
+	adjusted := raw.gasPrice
+	tm.gasPriceLock.Unlock()
+
+	updateGasPriceMetrics(adjusted)
+	log.Info("Gas price adjusted", "caption", raw.getCaption(),
+		"previous", gasPriceToString(previousGasPrice),
+		"adjusted", gasPriceToString(adjusted))
 }
 
 // clearQueue check raw transaction is mined. Mined raw transactions move to unconfirmed pending.
@@ -590,7 +970,6 @@ func (tm *TransactionManager) clearQueue(addr common.Address) {
 		if raw.Reverted {
 			log.Error("Transaction is reverted", "caption", raw.getCaption(), "hash", raw.MinedTxHash.String())
 		}
-		tm.adjustGasPrice(raw, true)
 	}
 
 	// remove mined raw transactions
@@ -618,12 +997,33 @@ func (tm *TransactionManager) clearQueue(addr common.Address) {
 		tm.pending[addr] = tm.pending[addr][l:]
 		WritePendingTxs(tm.db, addr, tm.pending[addr])
 		WriteUnconfirmedTxs(tm.db, addr, tm.unconfirmed[addr])
+		tm.updateQueueMetrics()
+	}
+
+	// queue again the replacements of the payloads mined before replaced
+	for _, raw := range minedRaws {
+		if raw.requeue == nil {
+			continue
+		}
+		WriteRawTxHash(tm.db, addr, raw)
+		tm.enqueue(addr, raw.requeue)
+		requeuedMeter.Mark(1)
+		log.Warn("Replaced transaction is mined, queue the replacement again", "caption", raw.requeue.getCaption(), "nonce", raw.requeue.Nonce, "from", addr)
+		raw.requeue = nil
 	}
 }
 
 // confirmQueue check mined raw transaction is confirmed.
 // If unconfirmed transaction is removed from canonical chain, insert it into pending pending.
 func (tm *TransactionManager) confirmQueue(addr common.Address) {
+	// the subscribers are notified after tm.lock is released
+	var events []ConfirmedEvent
+	defer func() {
+		for _, ev := range events {
+			tm.confirmedFeed.Send(ev)
+		}
+	}()
+
 	tm.lock.Lock()
 	defer tm.lock.Unlock()
 
@@ -649,6 +1049,7 @@ func (tm *TransactionManager) confirmQueue(addr common.Address) {
 		if removed {
 			log.Info("Raw transaction is removed", "addr", addr, "caption", raw.getCaption())
 			raw.PrepareToResend()
+			removedMeter.Mark(1)
 			tm.pending[addr] = append(tm.pending[addr], raw)
 		} else {
 			newUnconfirmed = append(newUnconfirmed, raw)
@@ -683,6 +1084,13 @@ func (tm *TransactionManager) confirmQueue(addr common.Address) {
 		tm.confirmed[addr] = append(tm.confirmed[addr], raw)
 		WriteConfirmedTx(tm.db, addr, numConfirmed, raw)
 		numConfirmed++
+
+		// the deadline is kept for a duplicate raw transaction not confirmed yet
+		if !tm.hasUnconfirmedRaw(addr, raw.Hash(), raw) {
+			DeleteRawTxDeadline(tm.db, addr, raw.Hash())
+		}
+
+		events = append(events, confirmedEvent(raw))
 	}
 
 	// update database
@@ -691,6 +1099,8 @@ func (tm *TransactionManager) confirmQueue(addr common.Address) {
 		WriteNumConfirmedRawTxs(tm.db, addr, numConfirmed)
 		WriteUnconfirmedTxs(tm.db, addr, tm.unconfirmed[addr])
 	}
+
+	tm.updateQueueMetrics()
 }
 
 func (tm *TransactionManager) indexOf(addr common.Address) int {
@@ -704,100 +1114,93 @@ func (tm *TransactionManager) indexOf(addr common.Address) int {
 	return -1
 }
 
-// TODO: use SubscribeNewHead with disconnection handling
-func (tm *TransactionManager) confirmLoop() {
-	closed := false
-
-	newHeaderCh := make(chan *types.Header)
-	sub, err := tm.backend.SubscribeNewHead(context.Background(), newHeaderCh)
-
-	close := func() {
-		defer func() {
-			if err := recover(); err != nil {
-				log.Error("New block event unsubscription", "err", err)
-			}
-		}()
-		sub.Unsubscribe()
-	}
-
-	defer close()
+// confirmDue returns whether the queues are confirmed at the head, i.e. the head
+// is at least ConfirmationDelay blocks after the last confirmation. The head may
+// be many blocks ahead as it is polled.
+func confirmDue(lastConfirmed, head uint64) bool {
+	return head >= lastConfirmed+ConfirmationDelay
+}
 
-	if err != nil {
-		log.Error("Failed to subscribe root chian new block event", "err", err)
-		return
-	}
+// confirmLoop polls the root chain head and confirms the mined raw
+// transactions. Only plain JSONRPC calls are used, so it works over HTTP
+// providers too.
+func (tm *TransactionManager) confirmLoop() {
+	ticker := time.NewTicker(headPollInterval)
+	defer ticker.Stop()
 
-	reconnTimer := time.NewTimer(0)
-	<-reconnTimer.C
+	var (
+		lastHead      common.Hash
+		lastConfirmed uint64
+	)
 
-	reconn := func() {
-		if closed {
+	for {
+		select {
+		case <-tm.quit:
 			return
+		case <-ticker.C:
 		}
 
-		sub2, err := tm.backend.SubscribeNewHead(context.Background(), newHeaderCh)
-
+		header, err := tm.backend.HeaderByNumber(context.Background(), nil)
 		if err != nil {
-			log.Error("Failed to re-subscribe root chian new block event", "err", err)
-			reconnTimer.Reset(5 * time.Second)
-		} else {
-			sub = sub2
-			log.Info("Re-subscribe root chian new block event", "err", err)
+			log.Error("Failed to read root chain head", "err", err)
+			continue
 		}
-	}
-
-	var lastConfirmed uint64
-
-	for {
-		select {
-		case <-tm.quit:
-			closed = true
-			reconnTimer.Stop()
-			return
+		if header.Hash() == lastHead {
+			continue
+		}
+		lastHead = header.Hash()
 
-		case header, ok := <-newHeaderCh:
-			if !ok {
-				continue
-			}
+		if lastConfirmed == 0 {
+			lastConfirmed = header.Number.Uint64()
+		}
 
-			if lastConfirmed == 0 {
-				lastConfirmed = header.Number.Uint64()
-			}
+		block, err := tm.backend.BlockByHash(context.Background(), header.Hash())
+		if err != nil {
+			log.Error("Failed to read root chain block", "err", err)
+			continue
+		}
 
-			block, err := tm.backend.BlockByHash(context.Background(), header.Hash())
-			if err != nil {
-				log.Error("Failed to read root chain block", "err", err)
-				continue
-			}
+		log.Info("New root chain block mined", "number", header.Number, "numTxs", len(block.Transactions()), "gasUsed", header.GasUsed, "gasLimit", header.GasLimit)
 
-			log.Info("New root chain block mined", "number", header.Number, "numTxs", len(block.Transactions()), "gasUsed", header.GasUsed, "gasLimit", header.GasLimit)
+		tm.lock.Lock()
+		tm.currentBlockNumber.Set(header.Number)
+		tm.lock.Unlock()
 
-			tm.lock.Lock()
-			tm.currentBlockNumber.Set(header.Number)
-			tm.lock.Unlock()
+		if !confirmDue(lastConfirmed, header.Number.Uint64()) {
+			continue
+		}
 
-			if lastConfirmed+ConfirmationDelay < header.Number.Uint64() {
-				continue
-			}
+		lastConfirmed = header.Number.Uint64()
+		for _, addr := range tm.addresses {
+			tm.confirmQueue(addr)
+		}
+	}
+}
 
-			lastConfirmed = header.Number.Uint64()
-			for _, addr := range tm.addresses {
-				tm.confirmQueue(addr)
-			}
+// waitNextBlock waits until the root chain head polled by confirmLoop is
+// beyond the block number. It returns false if the manager is stopped.
+func (tm *TransactionManager) waitNextBlock(number uint64) bool {
+	ticker := time.NewTicker(headPollInterval)
+	defer ticker.Stop()
 
-		case err := <-sub.Err():
-			log.Error("New block event unsubscribed", "err", err)
-			reconn()
+	for {
+		select {
+		case <-ticker.C:
+			tm.lock.RLock()
+			current := tm.currentBlockNumber.Uint64()
+			tm.lock.RUnlock()
 
-		case _, ok := <-reconnTimer.C:
-			if ok {
-				reconn()
+			if current > number {
+				return true
 			}
+		case <-tm.quit:
+			return false
 		}
 	}
 }
 
 func (tm *TransactionManager) Stop() {
+	tm.scope.Close()
 	close(tm.quit)
 }
 
@@ -18,7 +18,7 @@ import (
 	"github.com/Onther-Tech/plasma-evm/contracts/plasma/epochhandler"
 	"github.com/Onther-Tech/plasma-evm/core/rawdb"
 	"github.com/Onther-Tech/plasma-evm/crypto"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
 	"github.com/Onther-Tech/plasma-evm/ethdb"
 	"github.com/Onther-Tech/plasma-evm/log"
 	"github.com/Onther-Tech/plasma-evm/params"
@@ -70,8 +70,9 @@ var (
 	accs  []accounts.Account
 	opts  []*bind.TransactOpts
 
-	testConfig = DefaultConfig
-	backend    *ethclient.Client
+	testConfig  *Config
+	backend     *failover.Client
+	connectOnce sync.Once
 
 	defaultGasLimit uint64 = 7000000
 	defaultResubmit        = 3 * time.Second
@@ -84,22 +85,6 @@ func init() {
 	log.PrintOrigins(true)
 	log.Root().SetHandler(log.LvlFilterHandler(log.Lvl(*loglevel), log.StreamHandler(colorable.NewColorableStderr(), log.TerminalFormat(true))))
 
-	testConfig.Interval = defaultResubmit
-
-	backend, err = ethclient.Dial(rootchainUrl)
-	if err != nil {
-		log.Error("Failed to connect rootchain provider", "err", err)
-	}
-
-	networkId, err := backend.NetworkID(context.Background())
-	testConfig.ChainId = new(big.Int).Set(networkId)
-
-	if err != nil {
-		log.Error("Failed to read rootchain network id", "err", err)
-	}
-
-	log.Info("rootchain connected", "network id", networkId)
-
 	for _, hex := range keysHex {
 		key, err := crypto.HexToECDSA(hex)
 		if err != nil {
@@ -114,6 +99,32 @@ func init() {
 	}
 }
 
+// connectRootChain connects the root chain provider the integration tests run
+// against, and skips the test if it is not running.
+func connectRootChain(t *testing.T) {
+	connectOnce.Do(func() {
+		config := *DefaultConfig
+		testConfig = &config
+		testConfig.Interval = defaultResubmit
+
+		if backend, err = failover.Dial(rootchainUrl); err != nil {
+			return
+		}
+
+		var networkId *big.Int
+		if networkId, err = backend.NetworkID(context.Background()); err != nil {
+			return
+		}
+		testConfig.ChainId = new(big.Int).Set(networkId)
+
+		log.Info("rootchain connected", "network id", networkId)
+	})
+
+	if err != nil {
+		t.Skipf("rootchain provider is not available at %s: %v", rootchainUrl, err)
+	}
+}
+
 func makeTestManager(db ethdb.Database) *TransactionManager {
 	d, err := ioutil.TempDir("", "pls-transaction-manager-test")
 	if err != nil {
@@ -133,12 +144,14 @@ func makeTestManager(db ethdb.Database) *TransactionManager {
 		}
 	}
 
-	tm, _ := NewTransactionManager(ks, backend, db, testConfig)
+	tm, _ := NewTransactionManager(accounts.NewManager(&accounts.Config{}, ks), backend, db, testConfig)
 
 	return tm
 }
 
 func TestBasic(t *testing.T) {
+	connectRootChain(t)
+
 	db := rawdb.NewMemoryDatabase()
 	tm := makeTestManager(db)
 
@@ -173,6 +186,8 @@ func TestBasic(t *testing.T) {
 }
 
 func TestRestart(t *testing.T) {
+	connectRootChain(t)
+
 	db := rawdb.NewMemoryDatabase()
 	tm := makeTestManager(db)
 	tm.Start()
@@ -229,6 +244,8 @@ func TestRestart(t *testing.T) {
 }
 
 func TestCongestedNetwork(t *testing.T) {
+	connectRootChain(t)
+
 	db := rawdb.NewMemoryDatabase()
 	tm := makeTestManager(db)
 
@@ -335,3 +352,31 @@ func TestCongestedNetwork(t *testing.T) {
 
 	tm.Stop()
 }
+
+// Tests that the queues are still confirmed when the polled head jumps more
+// than ConfirmationDelay blocks.
+func TestConfirmDueHeadJump(t *testing.T) {
+	heads := []struct {
+		number  uint64
+		confirm bool
+	}{
+		{101, false},
+		{100 + ConfirmationDelay + 1, true},     // jumps past the delay
+		{100 + 3*ConfirmationDelay + 2, true},   // jumps several delays
+		{100 + 3*ConfirmationDelay + 3, false},  // next block
+		{100 + 10*ConfirmationDelay, true},      // jumps again
+		{100 + 10*ConfirmationDelay + 1, false}, // next block
+		{100 + 11*ConfirmationDelay, true},      // exactly the delay
+	}
+
+	lastConfirmed := uint64(100)
+	for i, head := range heads {
+		confirm := confirmDue(lastConfirmed, head.number)
+		if confirm != head.confirm {
+			t.Fatalf("head %d (#%d): confirm mismatch: have %v, want %v", i, head.number, confirm, head.confirm)
+		}
+		if confirm {
+			lastConfirmed = head.number
+		}
+	}
+}
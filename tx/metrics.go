@@ -0,0 +1,87 @@
+package tx
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/Onther-Tech/plasma-evm/metrics"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+var (
+	pendingGauge     = metrics.NewRegisteredGauge("tx/queue/pending", nil)
+	unconfirmedGauge = metrics.NewRegisteredGauge("tx/queue/unconfirmed", nil)
+	confirmedGauge   = metrics.NewRegisteredGauge("tx/queue/confirmed", nil)
+
+	gasPriceGauge = metrics.NewRegisteredGauge("tx/gasprice", nil) // in gwei
+
+	sentMeter      = metrics.NewRegisteredMeter("tx/sent", nil)
+	resendMeter    = metrics.NewRegisteredMeter("tx/resend", nil)  // re-signed with another gas price
+	removedMeter   = metrics.NewRegisteredMeter("tx/removed", nil) // removed by root chain reorg
+	minedMeter     = metrics.NewRegisteredMeter("tx/mined", nil)
+	revertedMeter  = metrics.NewRegisteredMeter("tx/reverted", nil)
+	replacedMeter  = metrics.NewRegisteredMeter("tx/replaced", nil)
+	cancelledMeter = metrics.NewRegisteredMeter("tx/cancelled", nil)
+	requeuedMeter  = metrics.NewRegisteredMeter("tx/requeued", nil)  // replacements queued again after the replaced payload is mined
+	pipelinedMeter = metrics.NewRegisteredMeter("tx/pipelined", nil) // sent ahead of the first unmined one
+
+	deadlineMissedMeter = metrics.NewRegisteredMeter("tx/deadline/missed", nil)
+
+	nonceDriftMeter  = metrics.NewRegisteredMeter("tx/nonce/drift", nil)
+	nonceFilledMeter = metrics.NewRegisteredMeter("tx/nonce/filled", nil) // gaps filled with empty transactions
+)
+
+// captionKind returns the function name of the caption, e.g. "submitNRE" for
+// "submitNRE(1: [1-4])". It is used to break down metrics by the kind of raw
+// transaction.
+func captionKind(caption string) string {
+	if i := strings.IndexByte(caption, '('); i >= 0 {
+		caption = caption[:i]
+	}
+	caption = strings.TrimSpace(caption)
+
+	if caption == "" {
+		return "unknown"
+	}
+	return caption
+}
+
+// updateQueueMetrics updates queue depth gauges. tm.lock must be held.
+func (tm *TransactionManager) updateQueueMetrics() {
+	var pending, unconfirmed, confirmed int
+	for _, q := range tm.pending {
+		pending += len(q)
+	}
+	for _, q := range tm.unconfirmed {
+		unconfirmed += len(q)
+	}
+	for _, q := range tm.confirmed {
+		confirmed += len(q)
+	}
+
+	pendingGauge.Update(int64(pending))
+	unconfirmedGauge.Update(int64(unconfirmed))
+	confirmedGauge.Update(int64(confirmed))
+}
+
+// markMined updates metrics of the raw transaction mined with the gas price.
+func markMined(raw *RawTransaction, gasUsed uint64, gasPrice *big.Int) {
+	kind := captionKind(raw.Caption)
+
+	minedMeter.Mark(1)
+	if raw.Reverted {
+		revertedMeter.Mark(1)
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), gasPrice)
+	metrics.GetOrRegisterCounter("tx/gas/"+kind, nil).Inc(int64(gasUsed))
+	metrics.GetOrRegisterCounter("tx/fee/"+kind, nil).Inc(new(big.Int).Div(fee, big.NewInt(params.GWei)).Int64())
+
+	if !raw.addedAt.IsZero() {
+		metrics.GetOrRegisterTimer("tx/latency/"+kind, nil).UpdateSince(raw.addedAt)
+	}
+}
+
+func updateGasPriceMetrics(gasPrice *big.Int) {
+	gasPriceGauge.Update(new(big.Int).Div(gasPrice, big.NewInt(params.GWei)).Int64())
+}
@@ -0,0 +1,275 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/log"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+// NonceCheckInterval is the interval between the nonce reconciliations of the
+// accounts with the root chain.
+const NonceCheckInterval = 5 * time.Minute
+
+var errQueueChanged = errors.New("queue changed while reconciling nonces")
+
+// NonceReport is the result of reconciling the nonces of an account with the
+// root chain.
+type NonceReport struct {
+	Address common.Address
+	Local   uint64 // next nonce of the transaction manager before reconciliation
+	Next    uint64 // next nonce of the transaction manager after reconciliation
+	Mined   uint64 // account nonce at the latest root chain block
+	Pending uint64 // account nonce including the pending transactions of the root chain
+
+	Conflicts  []uint64 // nonces of raw transactions used by other transactions
+	Renumbered []uint64 // indices of raw transactions given a new nonce
+	Gaps       []uint64 // nonces filled with empty transactions
+
+	CheckedAt time.Time
+	Err       error
+}
+
+// Drifted returns whether the reconciliation found any difference with the
+// root chain.
+func (r *NonceReport) Drifted() bool {
+	return r.Local != r.Next || len(r.Conflicts) > 0 || len(r.Renumbered) > 0 || len(r.Gaps) > 0
+}
+
+// NonceReports returns the last nonce reconciliation result of the accounts.
+func (tm *TransactionManager) NonceReports() []*NonceReport {
+	tm.lock.RLock()
+	defer tm.lock.RUnlock()
+
+	reports := make([]*NonceReport, 0, len(tm.addresses))
+	for _, addr := range tm.addresses {
+		if report, ok := tm.nonceReports[addr]; ok {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+// ReconcileNonces reconciles the nonces of all accounts with the root chain
+// immediately.
+func (tm *TransactionManager) ReconcileNonces() []*NonceReport {
+	tm.lock.RLock()
+	addresses := append([]common.Address{}, tm.addresses...)
+	tm.lock.RUnlock()
+
+	reports := make([]*NonceReport, 0, len(addresses))
+	for _, addr := range addresses {
+		reports = append(reports, tm.reconcile(addr))
+	}
+	return reports
+}
+
+// requestReconcile schedules a nonce reconciliation of the account.
+func (tm *TransactionManager) requestReconcile(addr common.Address) {
+	select {
+	case tm.reconcileCh <- addr:
+	default:
+	}
+}
+
+// nonceLoop reconciles the nonces on start, periodically and on request.
+func (tm *TransactionManager) nonceLoop() {
+	tm.ReconcileNonces()
+
+	ticker := time.NewTicker(NonceCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tm.ReconcileNonces()
+		case addr := <-tm.reconcileCh:
+			tm.reconcile(addr)
+		case <-tm.quit:
+			return
+		}
+	}
+}
+
+// reconcile compares the nonces of the queued raw transactions with the root
+// chain and repairs them. Unmined raw transactions whose nonce is used by
+// another transaction, or which are out of sequence and not sent yet, are
+// renumbered in the queue order. Nonces skipped below the sent raw
+// transactions are filled with empty transactions, since the sent ones keep
+// their nonce to be found if they are mined.
+func (tm *TransactionManager) reconcile(addr common.Address) *NonceReport {
+	report := &NonceReport{Address: addr, CheckedAt: time.Now()}
+
+	if err := tm.reconcileNonces(report); err != nil {
+		log.Error("Failed to reconcile account nonce", "addr", addr, "err", err)
+		report.Err = err
+	} else if report.Drifted() {
+		nonceDriftMeter.Mark(1)
+		log.Warn("Account nonce reconciled", "addr", addr, "local", report.Local, "next", report.Next, "mined", report.Mined,
+			"pending", report.Pending, "conflicts", len(report.Conflicts), "renumbered", len(report.Renumbered), "gaps", len(report.Gaps))
+	}
+
+	tm.lock.Lock()
+	tm.nonceReports[addr] = report
+	tm.lock.Unlock()
+
+	return report
+}
+
+func (tm *TransactionManager) reconcileNonces(report *NonceReport) error {
+	addr := report.Address
+
+	var err error
+	if report.Mined, err = tm.backend.NonceAt(context.Background(), addr, nil); err != nil {
+		return err
+	}
+	if report.Pending, err = tm.backend.PendingNonceAt(context.Background(), addr); err != nil {
+		return err
+	}
+
+	tm.lock.RLock()
+	queue := append(RawTransactions{}, tm.pending[addr]...)
+	tm.lock.RUnlock()
+
+	// look up the raw transactions whose nonce is used, before any lock is held
+	conflicts := make(map[*RawTransaction]bool)
+	for _, raw := range queue {
+		if raw.Mined(tm.backend) || raw.Nonce.Uint64() >= report.Mined {
+			continue
+		}
+		mined, err := raw.CheckMined(tm.backend, false)
+		if err != nil {
+			return err
+		}
+		conflicts[raw] = !mined
+	}
+
+	// collect the gaps under the locks and fill them after releasing
+	gaps, err := func() ([]uint64, error) {
+		// wait until the raw transactions are not being sent
+		for _, raw := range queue {
+			raw.sendLock.Lock()
+			defer raw.sendLock.Unlock()
+		}
+
+		tm.lock.Lock()
+		defer tm.lock.Unlock()
+
+		if len(tm.pending[addr]) != len(queue) {
+			return nil, errQueueChanged
+		}
+		for i, raw := range tm.pending[addr] {
+			if raw != queue[i] {
+				return nil, errQueueChanged
+			}
+		}
+
+		report.Local = tm.nonce[addr]
+
+		var (
+			next   = report.Mined
+			gaps   []uint64
+			queued bool
+		)
+		for _, raw := range queue {
+			if raw.Mined(tm.backend) {
+				continue
+			}
+
+			nonce := raw.Nonce.Uint64()
+			if nonce < report.Mined {
+				// the nonce is used by a sent transaction or by another one
+				conflict, checked := conflicts[raw]
+				if !checked {
+					return nil, errQueueChanged
+				}
+				if !conflict {
+					continue
+				}
+				report.Conflicts = append(report.Conflicts, nonce)
+			}
+			queued = true
+
+			switch {
+			case nonce == next:
+				next++
+
+			case nonce > next && len(raw.PendingTxs) > 0:
+				// nonces below the pending transactions of the root chain are not skipped
+				from := next
+				if from < report.Pending {
+					from = report.Pending
+				}
+				for n := from; n < nonce; n++ {
+					gaps = append(gaps, n)
+				}
+				next = nonce + 1
+
+			default:
+				raw.Nonce = new(big.Int).SetUint64(next)
+				report.Renumbered = append(report.Renumbered, raw.Index)
+				next++
+			}
+		}
+
+		// new raw transactions must not replace the pending transactions sent elsewhere
+		if !queued && next < report.Pending {
+			next = report.Pending
+		}
+		report.Next = next
+
+		if len(report.Renumbered) > 0 {
+			WritePendingTxs(tm.db, addr, tm.pending[addr])
+		}
+		if tm.nonce[addr] != next {
+			tm.nonce[addr] = next
+			WriteAddrNonce(tm.db, addr, next)
+		}
+		return gaps, nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, nonce := range gaps {
+		if err := tm.fillNonce(addr, nonce); err != nil {
+			log.Error("Failed to fill nonce gap", "addr", addr, "nonce", nonce, "err", err)
+			continue
+		}
+		report.Gaps = append(report.Gaps, nonce)
+	}
+	return nil
+}
+
+// fillNonce sends an empty transaction to the sender itself at the nonce.
+func (tm *TransactionManager) fillNonce(addr common.Address, nonce uint64) error {
+	account := accounts.Account{Address: addr}
+
+	wallet, err := tm.am.Find(account)
+	if err != nil {
+		return err
+	}
+
+	tm.gasPriceLock.Lock()
+	gasPrice := new(big.Int).Set(tm.gasPrice)
+	tm.gasPriceLock.Unlock()
+
+	tx := types.NewTransaction(nonce, addr, big.NewInt(0), params.TxGas, gasPrice, nil)
+	signedTx, err := wallet.SignTx(account, tx, tm.config.ChainId)
+	if err != nil {
+		return err
+	}
+	if err := tm.backend.SendTransaction(context.Background(), signedTx); err != nil {
+		return err
+	}
+
+	nonceFilledMeter.Mark(1)
+	log.Info("Nonce gap filled", "addr", addr, "nonce", nonce, "hash", signedTx.Hash())
+	return nil
+}
@@ -0,0 +1,157 @@
+package tx
+
+import (
+	"io/ioutil"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Onther-Tech/plasma-evm/accounts"
+	"github.com/Onther-Tech/plasma-evm/accounts/keystore"
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/common/hexutil"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+)
+
+// nonceTestService serves the account nonces of a root chain where no
+// transaction of the test is mined.
+type nonceTestService struct {
+	mined, pending uint64
+	sent           int32
+	onSend         func()
+}
+
+func (s *nonceTestService) GetTransactionCount(addr common.Address, block string) hexutil.Uint64 {
+	if block == "pending" {
+		return hexutil.Uint64(s.pending)
+	}
+	return hexutil.Uint64(s.mined)
+}
+
+func (s *nonceTestService) GetTransactionReceipt(hash common.Hash) map[string]interface{} {
+	return nil
+}
+
+func (s *nonceTestService) SendRawTransaction(data hexutil.Bytes) common.Hash {
+	atomic.AddInt32(&s.sent, 1)
+	if s.onSend != nil {
+		s.onSend()
+	}
+	return common.Hash{}
+}
+
+func TestReconcileNonces(t *testing.T) {
+	service := &nonceTestService{mined: 5, pending: 5}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client, err := failover.Dial(httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	dir, err := ioutil.TempDir("", "pls-transaction-manager-nonce-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ks := keystore.NewKeyStore(dir, 2, 1)
+	account, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		t.Fatal(err)
+	}
+	addr := account.Address
+	to := common.HexToAddress("0x02")
+
+	// conflicts with a transaction sent elsewhere
+	conflicted := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{1}, false, "conflicted")
+	conflicted.Index, conflicted.Nonce = 0, big.NewInt(3)
+
+	// sent at a nonce skipping 6
+	sent := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{2}, false, "sent")
+	sent.Index, sent.Nonce = 1, big.NewInt(7)
+	sent.PendingTxs = types.Transactions{sent.ToTransaction(big.NewInt(100))}
+
+	queued := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{3}, false, "queued")
+	queued.Index, queued.Nonce = 2, big.NewInt(8)
+
+	tm := makeReplaceTestManager(t, addr, conflicted, sent, queued)
+	tm.am = accounts.NewManager(&accounts.Config{}, ks)
+	tm.backend = client
+	tm.config.ChainId = big.NewInt(1)
+	tm.addresses = []common.Address{addr}
+	tm.nonce = map[common.Address]uint64{addr: 20}
+	tm.nonceReports = make(map[common.Address]*NonceReport)
+
+	// gaps are filled without the manager and send locks held
+	var lockHeld bool
+	service.onSend = func() {
+		released := make(chan struct{})
+		go func() {
+			tm.lock.Lock()
+			sent.sendLock.Lock()
+			sent.sendLock.Unlock()
+			tm.lock.Unlock()
+			close(released)
+		}()
+		select {
+		case <-released:
+		case <-time.After(time.Second):
+			lockHeld = true
+		}
+	}
+
+	reports := tm.ReconcileNonces()
+	if len(reports) != 1 {
+		t.Fatalf("reports length mismatch: have %d, want %d", len(reports), 1)
+	}
+	report := reports[0]
+	if report.Err != nil {
+		t.Fatalf("failed to reconcile nonces: %v", report.Err)
+	}
+	if report.Local != 20 || report.Next != 9 || report.Mined != 5 || report.Pending != 5 {
+		t.Errorf("report nonces mismatch: %+v", report)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0] != 3 {
+		t.Errorf("conflicts mismatch: have %v, want [3]", report.Conflicts)
+	}
+	if len(report.Renumbered) != 1 || report.Renumbered[0] != conflicted.Index {
+		t.Errorf("renumbered mismatch: have %v, want [%d]", report.Renumbered, conflicted.Index)
+	}
+	if len(report.Gaps) != 1 || report.Gaps[0] != 6 || service.sent != 1 {
+		t.Errorf("gaps mismatch: have %v (%d sent), want [6]", report.Gaps, service.sent)
+	}
+	if lockHeld {
+		t.Error("gap filled with locks held")
+	}
+
+	if conflicted.Nonce.Uint64() != 5 || sent.Nonce.Uint64() != 7 || queued.Nonce.Uint64() != 8 {
+		t.Errorf("nonces mismatch: have %v %v %v, want 5 7 8", conflicted.Nonce, sent.Nonce, queued.Nonce)
+	}
+	if nonce := ReadAddrNonce(tm.db, addr); nonce != 9 {
+		t.Errorf("stored nonce mismatch: have %d, want %d", nonce, 9)
+	}
+	if pending := ReadPendingTxs(tm.db, addr); len(pending) != 3 || pending[0].Nonce.Uint64() != 5 {
+		t.Errorf("stored pending transactions mismatch")
+	}
+
+	// nothing to repair once the transactions are pending in the root chain
+	service.pending = 9
+	if report := tm.ReconcileNonces()[0]; report.Err != nil || report.Drifted() {
+		t.Errorf("unexpected drift: %+v", report)
+	}
+}
@@ -0,0 +1,172 @@
+package tx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core"
+	"github.com/Onther-Tech/plasma-evm/params"
+)
+
+// pipelineTestBlockGasLimit is the gas limit of the simulated root chain blocks.
+const pipelineTestBlockGasLimit = 12500000
+
+// pipelineTestChain is a root chain mining the sent transactions in the order
+// of the nonces as many as the block gas limit allows.
+type pipelineTestChain struct {
+	nonce   uint64
+	mempool map[uint64]*RawTransaction
+
+	blocks  uint64
+	gasUsed uint64 // intrinsic gas of the mined transactions
+}
+
+func newPipelineTestChain() *pipelineTestChain {
+	return &pipelineTestChain{mempool: make(map[uint64]*RawTransaction)}
+}
+
+func (c *pipelineTestChain) send(addr common.Address, raw *RawTransaction) (common.Hash, error) {
+	tx := raw.ToTransaction(big.NewInt(params.GWei))
+	if err := raw.AddPending(tx); err != nil {
+		return common.Hash{}, err
+	}
+	c.mempool[raw.Nonce.Uint64()] = raw
+	return tx.Hash(), nil
+}
+
+func (c *pipelineTestChain) mine() {
+	c.blocks++
+
+	var gas uint64
+	for {
+		raw, ok := c.mempool[c.nonce]
+		if !ok || gas+raw.GasLimit > pipelineTestBlockGasLimit {
+			return
+		}
+		gas += raw.GasLimit
+
+		intrinsic, _ := core.IntrinsicGas(raw.Payload, false, true, true)
+		c.gasUsed += intrinsic
+
+		raw.MinedTxHash = raw.PendingTxs[0].Hash()
+		raw.MinedBlockNumber = new(big.Int).SetUint64(c.blocks)
+		delete(c.mempool, c.nonce)
+		c.nonce++
+	}
+}
+
+func makePipelineTestManager(t testing.TB, pipeline int, nonces ...uint64) (*TransactionManager, common.Address, RawTransactions, func()) {
+	client, closeFn := dialNonceTestService(t)
+
+	addr := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+
+	// payload of submitORB(pos, statesRoot, transactionsRoot, receiptsRoot)
+	payload := make([]byte, 4+4*common.HashLength)
+	for i := range payload {
+		payload[i] = byte(i + 1)
+	}
+
+	raws := make(RawTransactions, len(nonces))
+	for i, nonce := range nonces {
+		payload := append([]byte{}, payload...)
+		payload[len(payload)-1] = byte(i)
+
+		raws[i] = NewRawTransaction(addr, params.SubmitBlockGasLimit, &to, big.NewInt(0), payload, false, "submitORB")
+		raws[i].Index, raws[i].Nonce = uint64(i), new(big.Int).SetUint64(nonce)
+	}
+
+	tm := makeReplaceTestManager(t, addr, raws...)
+	tm.backend = client
+	tm.config.Pipeline = pipeline
+	return tm, addr, raws, closeFn
+}
+
+// Tests that the raw transactions following the first unmined one are sent
+// ahead up to the limit, and not beyond a gap in the nonces.
+func TestSendAhead(t *testing.T) {
+	tm, addr, raws, closeFn := makePipelineTestManager(t, 2, 0, 1, 2, 3, 5)
+	defer closeFn()
+	chain := newPipelineTestChain()
+
+	chain.send(addr, raws[0])
+	tm.sendAhead(addr, tm.pending[addr], raws[0], chain.send)
+	if len(chain.mempool) != 3 || chain.mempool[1] != raws[1] || chain.mempool[2] != raws[2] {
+		t.Fatalf("sent transactions mismatch: have %d, want nonces 0-2", len(chain.mempool))
+	}
+
+	// the transactions sent ahead are not sent again
+	tm.config.Pipeline = 10
+	tm.sendAhead(addr, tm.pending[addr], raws[0], chain.send)
+	if len(chain.mempool) != 4 || chain.mempool[3] != raws[3] || len(raws[1].PendingTxs) != 1 {
+		t.Fatalf("sent transactions mismatch: have %d, want nonces 0-3", len(chain.mempool))
+	}
+
+	// three transactions fit in a block, and the mined ones are skipped
+	chain.mine()
+	tm.clearQueue(addr)
+	if len(tm.pending[addr]) != 2 || tm.pending[addr][0] != raws[3] {
+		t.Fatalf("pending transactions mismatch: have %d, want 2", len(tm.pending[addr]))
+	}
+	tm.sendAhead(addr, tm.pending[addr], raws[3], chain.send)
+	if len(chain.mempool) != 1 {
+		t.Fatalf("sent transactions mismatch: have %d, want 1", len(chain.mempool))
+	}
+
+	// only the ORB submissions are sent ahead
+	tm, addr, raws, closeFn = makePipelineTestManager(t, 10, 0, 1, 2)
+	defer closeFn()
+	raws[1].Caption = "submitNRE(1: [1-4])"
+	chain = newPipelineTestChain()
+
+	chain.send(addr, raws[0])
+	tm.sendAhead(addr, tm.pending[addr], raws[0], chain.send)
+	if len(chain.mempool) != 1 {
+		t.Fatalf("sent transactions mismatch: have %d, want 1", len(chain.mempool))
+	}
+}
+
+// benchmarkPipeline submits ORBs through the transaction manager to a root
+// chain mining a block per send interval, and reports the root chain blocks
+// and the intrinsic gas spent per ORB.
+func benchmarkPipeline(b *testing.B, pipeline int) {
+	const numORBs = 32
+
+	nonces := make([]uint64, numORBs)
+	for i := range nonces {
+		nonces[i] = uint64(i)
+	}
+
+	var blocks, gasUsed uint64
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tm, addr, _, closeFn := makePipelineTestManager(b, pipeline, nonces...)
+		chain := newPipelineTestChain()
+		b.StartTimer()
+
+		for {
+			tm.clearQueue(addr)
+
+			queue := tm.pending[addr]
+			if len(queue) == 0 {
+				break
+			}
+			if head := queue[0]; len(head.PendingTxs) == 0 {
+				chain.send(addr, head)
+			}
+			tm.sendAhead(addr, queue, queue[0], chain.send)
+
+			chain.mine()
+		}
+		blocks += chain.blocks
+		gasUsed += chain.gasUsed
+		closeFn()
+	}
+
+	b.ReportMetric(float64(blocks)/float64(b.N*numORBs), "blocks/orb")
+	b.ReportMetric(float64(gasUsed)/float64(b.N*numORBs), "gas/orb")
+}
+
+func BenchmarkSubmitORBSerial(b *testing.B)    { benchmarkPipeline(b, 0) }
+func BenchmarkSubmitORBPipelined(b *testing.B) { benchmarkPipeline(b, DefaultConfig.Pipeline) }
@@ -34,8 +34,28 @@ var (
 	pendingTxsPrefix      = []byte("pending-raw-txs")       // pendingTxsPrefix + account address -> (resend + pending) raw transactions
 
 	rawTxHashPrefix = []byte("raw-tx-hash") // rawTxHashPrefix + account address + raw transaction hash -> raw transaction without index
+
+	rawTxDeadlinePrefix = []byte("raw-tx-deadline") // rawTxDeadlinePrefix + account address + raw transaction hash -> deadline root chain block number
 )
 
+// KeyPrefixes returns the prefixes of every database key written by the
+// transaction manager.
+func KeyPrefixes() [][]byte {
+	return [][]byte{
+		gasPriceKey,
+		numAddrKey,
+		addrPrefix,
+		addrNoncePrefix,
+		numRawTxsPrefix,
+		numConfirmedTxsPrefix,
+		confirmedTxsPrefix,
+		unconfirmedTxsPrefix,
+		pendingTxsPrefix,
+		rawTxHashPrefix,
+		rawTxDeadlinePrefix,
+	}
+}
+
 func ReadGasPrice(db ethdb.Reader) *big.Int {
 	data, _ := db.Get(gasPriceKey)
 
@@ -318,7 +338,7 @@ func ReadRawTxHash(db ethdb.Reader, addr common.Address, rawHash common.Hash) *R
 	return &raw
 }
 
-func WriteRawTxHash(db ethdb.KeyValueWriter, addr common.Address, raw RawTransaction) {
+func WriteRawTxHash(db ethdb.KeyValueWriter, addr common.Address, raw *RawTransaction) {
 	data, err := rlp.EncodeToBytes(raw)
 	if err != nil {
 		log.Crit("Failed to encode raw transaction", "err", err)
@@ -328,9 +348,41 @@ func WriteRawTxHash(db ethdb.KeyValueWriter, addr common.Address, raw RawTransac
 	}
 }
 
+func DeleteRawTxHash(db ethdb.KeyValueWriter, addr common.Address, rawHash common.Hash) {
+	if err := db.Delete(rawTxHashKey(addr, rawHash)); err != nil {
+		log.Crit("Failed to delete raw transaction", "err", err)
+	}
+}
+
 // encodeBlockNumber encodes a number as big endian uint64
 func encodeNumber(number uint64) []byte {
 	enc := make([]byte, 8)
 	binary.BigEndian.PutUint64(enc, number)
 	return enc
 }
+
+func rawTxDeadlineKey(addr common.Address, rawHash common.Hash) []byte {
+	return append(append(rawTxDeadlinePrefix, addr.Bytes()...), rawHash.Bytes()...)
+}
+
+// ReadRawTxDeadline returns the deadline of the raw transaction, or zero if it
+// has no deadline.
+func ReadRawTxDeadline(db ethdb.Reader, addr common.Address, rawHash common.Hash) uint64 {
+	data, _ := db.Get(rawTxDeadlineKey(addr, rawHash))
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+func WriteRawTxDeadline(db ethdb.KeyValueWriter, addr common.Address, rawHash common.Hash, deadline uint64) {
+	if err := db.Put(rawTxDeadlineKey(addr, rawHash), encodeNumber(deadline)); err != nil {
+		log.Crit("Failed to store raw transaction deadline", "err", err)
+	}
+}
+
+func DeleteRawTxDeadline(db ethdb.KeyValueWriter, addr common.Address, rawHash common.Hash) {
+	if err := db.Delete(rawTxDeadlineKey(addr, rawHash)); err != nil {
+		log.Crit("Failed to delete raw transaction deadline", "err", err)
+	}
+}
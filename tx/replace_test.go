@@ -0,0 +1,180 @@
+package tx
+
+import (
+	"bytes"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Onther-Tech/plasma-evm/common"
+	"github.com/Onther-Tech/plasma-evm/core/rawdb"
+	"github.com/Onther-Tech/plasma-evm/core/types"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
+	"github.com/Onther-Tech/plasma-evm/rpc"
+)
+
+// dialNonceTestService returns a client of a root chain where no transaction
+// of the test is mined.
+func dialNonceTestService(t testing.TB) (*failover.Client, func()) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", new(nonceTestService)); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+
+	client, err := failover.Dial(httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, func() {
+		client.Close()
+		httpServer.Close()
+	}
+}
+
+func makeReplaceTestManager(t testing.TB, addr common.Address, raws ...*RawTransaction) *TransactionManager {
+	pricer, err := NewGasPricer(GasPricerConfig{Strategy: GasPricerFixed, Escalation: 20}, nil, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := &TransactionManager{
+		config: &Config{
+			MinGasPrice: big.NewInt(1),
+			MaxGasPrice: big.NewInt(1000),
+		},
+		db:                 rawdb.NewMemoryDatabase(),
+		currentBlockNumber: big.NewInt(10),
+		gasPrice:           big.NewInt(100),
+		pricer:             pricer,
+		confirmed:          map[common.Address]RawTransactions{addr: {}},
+		unconfirmed:        map[common.Address]RawTransactions{addr: {}},
+		pending:            map[common.Address]RawTransactions{addr: {}},
+	}
+	for _, raw := range raws {
+		WriteRawTxHash(tm.db, addr, raw)
+		if (raw.MinedTxHash != common.Hash{}) {
+			tm.unconfirmed[addr] = append(tm.unconfirmed[addr], raw)
+		} else {
+			tm.pending[addr] = append(tm.pending[addr], raw)
+		}
+	}
+	return tm
+}
+
+// Tests that the replaced and cancelled raw transactions keep their index and
+// nonce, and that the replacements outbid the transactions already sent.
+func TestReplaceRawTransaction(t *testing.T) {
+	addr := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+
+	mined := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{0}, false, "mined")
+	mined.Index, mined.Nonce, mined.MinedTxHash = 0, big.NewInt(0), common.HexToHash("0x01")
+
+	first := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{1}, false, "first")
+	first.Index, first.Nonce = 1, big.NewInt(1)
+	first.PendingTxs = types.Transactions{first.ToTransaction(big.NewInt(100))}
+
+	second := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{2}, false, "second")
+	second.Index, second.Nonce = 2, big.NewInt(2)
+
+	tm := makeReplaceTestManager(t, addr, mined, first, second)
+	client, closeFn := dialNonceTestService(t)
+	defer closeFn()
+	tm.backend = client
+
+	replacement := NewRawTransaction(addr, 50000, &to, big.NewInt(0), []byte{3}, false, "replacement")
+	if err := tm.Replace(first, replacement); err != nil {
+		t.Fatalf("failed to replace raw transaction: %v", err)
+	}
+	if err := tm.Replace(second, replacement); err != ErrDuplicateRaw {
+		t.Fatalf("replace error mismatch: have %v, want %v", err, ErrDuplicateRaw)
+	}
+	if err := tm.Cancel(second); err != nil {
+		t.Fatalf("failed to cancel raw transaction: %v", err)
+	}
+	if err := tm.Cancel(mined); err != ErrMinedRaw {
+		t.Fatalf("cancel error mismatch: have %v, want %v", err, ErrMinedRaw)
+	}
+	if err := tm.Cancel(&RawTransaction{From: addr, Index: 3}); err != ErrUnknownRaw {
+		t.Fatalf("cancel error mismatch: have %v, want %v", err, ErrUnknownRaw)
+	}
+
+	pending := ReadPendingTxs(tm.db, addr)
+	if len(pending) != 2 {
+		t.Fatalf("pending length mismatch: have %d, want %d", len(pending), 2)
+	}
+	if p := pending[0]; p.Index != 1 || p.Nonce.Uint64() != 1 || !bytes.Equal(p.Payload, []byte{3}) || p.GasLimit != 50000 || len(p.PendingTxs) != 1 {
+		t.Errorf("replaced raw transaction mismatch: %+v", p)
+	}
+	if p := pending[1]; p.Index != 2 || p.Nonce.Uint64() != 2 || *p.Recipient != addr || len(p.Payload) != 0 {
+		t.Errorf("cancelled raw transaction mismatch: %+v", p)
+	}
+	if price := first.gasPrice; price == nil || price.Int64() != 120 {
+		t.Errorf("replacement gas price mismatch: have %v, want %v", price, 120)
+	}
+
+	if ReadRawTxHash(tm.db, addr, NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{1}, false, "").Hash()) != nil {
+		t.Errorf("replaced raw transaction is not removed")
+	}
+	if ReadRawTxHash(tm.db, addr, replacement.Hash()) == nil {
+		t.Errorf("replacement raw transaction is not stored")
+	}
+}
+
+// Tests that the payload of the mined transaction sent before the replacement
+// is restored, and that the replacement is queued again at a new nonce.
+func TestRequeueReplacement(t *testing.T) {
+	addr := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+
+	raw := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{1}, false, "first")
+	raw.Index, raw.Nonce = 0, big.NewInt(0)
+	sent := raw.ToTransaction(big.NewInt(100))
+	raw.PendingTxs = types.Transactions{sent}
+
+	tm := makeReplaceTestManager(t, addr, raw)
+	tm.nonce = map[common.Address]uint64{addr: 1}
+	WriteNumRawTxs(tm.db, addr, 1)
+	client, closeFn := dialNonceTestService(t)
+	defer closeFn()
+	tm.backend = client
+
+	replacement := NewRawTransaction(addr, 50000, &to, big.NewInt(0), []byte{2}, false, "replacement")
+	replacement.Deadline = 20
+	if err := tm.Replace(raw, replacement); err != nil {
+		t.Fatalf("failed to replace raw transaction: %v", err)
+	}
+
+	// the transaction sent before the replacement is mined
+	raw.MinedTxHash = sent.Hash()
+	raw.restoreMined(sent)
+	tm.clearQueue(addr)
+
+	if len(tm.unconfirmed[addr]) != 1 || !bytes.Equal(raw.Payload, []byte{1}) || raw.Caption != "replaced(replacement)" {
+		t.Fatalf("mined raw transaction mismatch: %+v", raw)
+	}
+	if ReadRawTxHash(tm.db, addr, raw.Hash()) == nil {
+		t.Errorf("mined raw transaction is not stored")
+	}
+	pending := ReadPendingTxs(tm.db, addr)
+	if len(pending) != 1 {
+		t.Fatalf("pending length mismatch: have %d, want %d", len(pending), 1)
+	}
+	if p := pending[0]; p.Index != 1 || p.Nonce.Uint64() != 1 || !bytes.Equal(p.Payload, []byte{2}) || p.Caption != "replacement" {
+		t.Errorf("requeued raw transaction mismatch: %+v", p)
+	}
+	if deadline := ReadRawTxDeadline(tm.db, addr, replacement.Hash()); deadline != 20 {
+		t.Errorf("requeued deadline mismatch: have %d, want %d", deadline, 20)
+	}
+
+	// a mined cancel is not queued again
+	cancelled := NewRawTransaction(addr, 21000, &addr, big.NewInt(0), nil, false, "cancel(cancelled)")
+	cancelled.Nonce = big.NewInt(2)
+	previous := NewRawTransaction(addr, 21000, &to, big.NewInt(0), []byte{3}, false, "cancelled")
+	previous.Nonce = cancelled.Nonce
+	cancelled.restoreMined(previous.ToTransaction(big.NewInt(100)))
+	if cancelled.requeue != nil || cancelled.Caption != "replaced(cancel(cancelled))" {
+		t.Errorf("cancel is queued again: %+v", cancelled)
+	}
+}
@@ -6,11 +6,12 @@ import (
 	"github.com/pkg/errors"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/Onther-Tech/plasma-evm"
 	"github.com/Onther-Tech/plasma-evm/common"
 	"github.com/Onther-Tech/plasma-evm/core/types"
-	"github.com/Onther-Tech/plasma-evm/ethclient"
+	"github.com/Onther-Tech/plasma-evm/ethclient/failover"
 )
 
 const (
@@ -50,10 +51,46 @@ type RawTransaction struct {
 
 	Caption string
 
+	// Root chain block number by which the transaction should be mined, zero
+	// if it has none. It is stored apart from the raw transaction.
+	Deadline uint64 `rlp:"-"`
+
+	addedAt time.Time // not persisted, only to measure latency until mined
+
+	// not persisted, restored from the pending transactions
+	gasPrice       *big.Int // gas price to sign the transaction with
+	firstSentBlock uint64   // root chain block number the transaction was sent first
+	deadlineMissed bool     // whether the missed deadline is alerted
+
+	// not persisted, the cost of the mined transaction
+	gasUsed        uint64
+	minedGasPrice  *big.Int    // nil if the raw transaction is mined before restart
+	minedBlockHash common.Hash // root chain block the transaction is mined in
+
+	// not persisted, the replacement to queue again if a transaction sent
+	// before the payload was replaced is mined
+	requeue *RawTransaction
+
 	sendLock sync.Mutex
 	lock     sync.RWMutex
 }
 
+// DeadlineMissedEvent is posted when a raw transaction is not mined by its
+// deadline.
+type DeadlineMissedEvent struct {
+	Raw         *RawTransaction
+	BlockNumber uint64 // root chain block number the deadline is found missed at
+}
+
+// ConfirmedEvent is posted when a raw transaction is confirmed, with the cost
+// of the transaction mined for it. The cost is not known if the raw
+// transaction is mined before restart, and the gas price is nil then.
+type ConfirmedEvent struct {
+	Raw      *RawTransaction
+	GasUsed  uint64
+	GasPrice *big.Int
+}
+
 func NewRawTransaction(from common.Address, gasLimit uint64, receipt *common.Address, amount *big.Int, payload []byte, allowRevert bool, caption string) *RawTransaction {
 	rawTx := &RawTransaction{
 		From:        from,
@@ -112,7 +149,7 @@ func (raw *RawTransaction) AddPending(tx *types.Transaction) error {
 }
 
 // CheckMined clears all pending transactions and sets mined transaction hash if transaction is mined .
-func (raw *RawTransaction) CheckMined(backend *ethclient.Client, force bool) (mined bool, err error) {
+func (raw *RawTransaction) CheckMined(backend *failover.Client, force bool) (mined bool, err error) {
 	raw.lock.Lock()
 	defer raw.lock.Unlock()
 
@@ -135,6 +172,9 @@ func (raw *RawTransaction) CheckMined(backend *ethclient.Client, force bool) (mi
 		raw.Reverted = receipt.Status == 0
 		raw.MinedBlockNumber = receipt.BlockNumber
 		raw.MinedTxHash = tx.Hash()
+		raw.gasUsed, raw.minedGasPrice, raw.minedBlockHash = receipt.GasUsed, tx.GasPrice(), receipt.BlockHash
+		raw.restoreMined(tx)
+		markMined(raw, receipt.GasUsed, tx.GasPrice())
 
 		mined = true
 		break
@@ -147,7 +187,7 @@ func (raw *RawTransaction) CheckMined(backend *ethclient.Client, force bool) (mi
 	return mined, nil
 }
 
-func (raw *RawTransaction) Mined(backend *ethclient.Client) bool {
+func (raw *RawTransaction) Mined(backend *failover.Client) bool {
 	raw.lock.Lock()
 	defer raw.lock.Unlock()
 
@@ -155,7 +195,7 @@ func (raw *RawTransaction) Mined(backend *ethclient.Client) bool {
 }
 
 // Removed returns whether the mined transaction is removed from ethereum blockchain.
-func (raw *RawTransaction) Removed(backend *ethclient.Client) (removed bool, err error) {
+func (raw *RawTransaction) Removed(backend *failover.Client) (removed bool, err error) {
 	receipt, err := backend.TransactionReceipt(context.Background(), raw.MinedTxHash)
 
 	if err == ethereum.NotFound {
@@ -173,6 +213,47 @@ func (raw *RawTransaction) Removed(backend *ethclient.Client) (removed bool, err
 	return false, nil
 }
 
+// replaceWith replaces the payload of the raw transaction with the one of r.
+// The index, nonce and sent transactions are kept, so the replacement is sent
+// at the same nonce and the replaced transaction is still found if it is mined.
+func (raw *RawTransaction) replaceWith(r *RawTransaction) {
+	raw.lock.Lock()
+	defer raw.lock.Unlock()
+
+	raw.GasLimit = r.GasLimit
+	raw.Recipient = r.Recipient
+	raw.Amount = r.Amount
+	raw.Payload = r.Payload
+	raw.AllowRevert = r.AllowRevert
+	raw.Caption = r.Caption
+	raw.Deadline = r.Deadline
+	raw.ResendCount = 0
+	raw.deadlineMissed = false
+}
+
+// restoreMined restores the payload of the mined transaction if it was sent
+// before the payload was replaced. The replacement is kept to be queued again
+// unless it cancels the raw transaction. raw.lock must be held.
+func (raw *RawTransaction) restoreMined(tx *types.Transaction) {
+	mined := toRawTransaction(raw.From, tx, raw.AllowRevert, fmt.Sprintf("replaced(%s)", raw.Caption))
+	if mined.Hash() == raw.Hash() {
+		return
+	}
+
+	if captionKind(raw.Caption) != "cancel" {
+		requeue := NewRawTransaction(raw.From, raw.GasLimit, raw.Recipient, raw.Amount, raw.Payload, raw.AllowRevert, raw.Caption)
+		requeue.Deadline = raw.Deadline
+		raw.requeue = requeue
+	}
+
+	raw.GasLimit = mined.GasLimit
+	raw.Recipient = mined.Recipient
+	raw.Amount = mined.Amount
+	raw.Payload = mined.Payload
+	raw.Caption = mined.Caption
+	raw.Deadline = 0
+}
+
 func (raw *RawTransaction) PrepareToResend() {
 	raw.ResendCount++
 	raw.MinedTxHash = common.Hash{}
@@ -184,7 +265,7 @@ func (raw *RawTransaction) PrepareToResend() {
 	raw.PendingTxs = make(types.Transactions, 0)
 }
 
-func (raw *RawTransaction) Confirmed(backend *ethclient.Client, currentBlockNumber *big.Int) bool {
+func (raw *RawTransaction) Confirmed(backend *failover.Client, currentBlockNumber *big.Int) bool {
 	raw.lock.Lock()
 	defer raw.lock.Unlock()
 
@@ -202,6 +283,7 @@ func (raw *RawTransaction) Confirmed(backend *ethclient.Client, currentBlockNumb
 		return false
 	}
 	raw.MinedBlockNumber = receipt.BlockNumber
+	raw.gasUsed, raw.minedBlockHash = receipt.GasUsed, receipt.BlockHash
 
 	if new(big.Int).Add(receipt.BlockNumber, big.NewInt(Confirmation)).Cmp(currentBlockNumber) > 0 {
 		return false